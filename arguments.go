@@ -0,0 +1,293 @@
+package main
+
+// Tool argument structs. Each tool handler binds its arguments into one of
+// these types with bindArguments, and the tool's input schema is generated
+// from the same type; see argumentField for the tag conventions. Feeds and
+// categories are referenced with the embedded feedReference and
+// categoryReference types, which also accept names.
+
+type noArgs struct{}
+
+// Feed Arguments
+type feedsArgs struct {
+	Fields []string `json:"fields" description:"Return only these feed fields, using dots for nested fields (for example id, title, feed_url, category.title)"`
+}
+
+type feedArgs struct {
+	feedReference
+}
+
+type createFeedArgs struct {
+	FeedURL string `json:"feed_url" required:"true" description:"The URL of the RSS/Atom feed to add"`
+	categoryReference
+	Crawler                     bool   `json:"crawler" default:"false" description:"Enable web scraper for full content"`
+	UserAgent                   string `json:"user_agent" description:"Custom user agent for feed fetching"`
+	Cookie                      string `json:"cookie" description:"Cookie header for feed fetching"`
+	Username                    string `json:"username" description:"Username for HTTP basic authentication"`
+	Password                    string `json:"password" description:"Password for HTTP basic authentication"`
+	ScraperRules                string `json:"scraper_rules" description:"CSS selectors for scraping article content"`
+	RewriteRules                string `json:"rewrite_rules" description:"Content rewrite rules"`
+	UrlRewriteRules             string `json:"urlrewrite_rules" description:"URL rewrite rules"`
+	BlocklistRules              string `json:"blocklist_rules" description:"Entry blocklist rules"`
+	KeeplistRules               string `json:"keeplist_rules" description:"Entry keeplist rules"`
+	BlockFilterEntryRules       string `json:"block_filter_entry_rules" description:"Entry block filter rules"`
+	KeepFilterEntryRules        string `json:"keep_filter_entry_rules" description:"Entry keep filter rules"`
+	Disabled                    bool   `json:"disabled" default:"false" description:"Add the feed without fetching it"`
+	IgnoreHTTPCache             bool   `json:"ignore_http_cache" default:"false" description:"Ignore HTTP cache headers"`
+	IgnoreEntryUpdates          bool   `json:"ignore_entry_updates" default:"false" description:"Ignore updates of entries already fetched"`
+	NoMediaPlayer               bool   `json:"no_media_player" default:"false" description:"Do not show a media player for enclosures"`
+	AllowSelfSignedCertificates bool   `json:"allow_self_signed_certificates" default:"false" description:"Allow self-signed TLS certificates"`
+	FetchViaProxy               bool   `json:"fetch_via_proxy" default:"false" description:"Fetch the feed through the configured proxy"`
+	HideGlobally                bool   `json:"hide_globally" default:"false" description:"Hide feed entries from the global list"`
+	DisableHTTP2                bool   `json:"disable_http2" default:"false" description:"Disable HTTP/2 when fetching the feed"`
+	ProxyURL                    string `json:"proxy_url" description:"Proxy URL used to fetch the feed"`
+}
+
+type subscribeArgs struct {
+	URL string `json:"url" required:"true" description:"The URL of a website or feed to subscribe to"`
+	categoryReference
+	Pick *string `json:"pick" description:"The rank or URL of the discovered feed to subscribe to, from the candidates of a previous call"`
+}
+
+type updateFeedArgs struct {
+	feedReference
+	FeedURL *string `json:"feed_url" description:"New RSS/Atom feed URL"`
+	SiteURL *string `json:"site_url" description:"New website URL"`
+	Title   *string `json:"title" description:"New feed title"`
+	categoryReference
+	ScraperRules                *string `json:"scraper_rules" description:"CSS selectors for scraping article content"`
+	RewriteRules                *string `json:"rewrite_rules" description:"Content rewrite rules"`
+	UrlRewriteRules             *string `json:"urlrewrite_rules" description:"URL rewrite rules"`
+	BlocklistRules              *string `json:"blocklist_rules" description:"Entry blocklist rules"`
+	KeeplistRules               *string `json:"keeplist_rules" description:"Entry keeplist rules"`
+	BlockFilterEntryRules       *string `json:"block_filter_entry_rules" description:"Entry block filter rules"`
+	KeepFilterEntryRules        *string `json:"keep_filter_entry_rules" description:"Entry keep filter rules"`
+	Crawler                     *bool   `json:"crawler" description:"Enable or disable full-content scraping"`
+	UserAgent                   *string `json:"user_agent" description:"Custom user agent for feed fetching"`
+	Cookie                      *string `json:"cookie" description:"Cookie header for feed fetching"`
+	Username                    *string `json:"username" description:"Username for HTTP basic authentication"`
+	Password                    *string `json:"password" description:"Password for HTTP basic authentication"`
+	Disabled                    *bool   `json:"disabled" description:"Enable or disable feed fetching"`
+	IgnoreHTTPCache             *bool   `json:"ignore_http_cache" description:"Ignore HTTP cache headers"`
+	AllowSelfSignedCertificates *bool   `json:"allow_self_signed_certificates" description:"Allow self-signed TLS certificates"`
+	FetchViaProxy               *bool   `json:"fetch_via_proxy" description:"Fetch the feed through the configured proxy"`
+	HideGlobally                *bool   `json:"hide_globally" description:"Hide feed entries from the global list"`
+	DisableHTTP2                *bool   `json:"disable_http2" description:"Disable HTTP/2 when fetching the feed"`
+	ProxyURL                    *string `json:"proxy_url" description:"Proxy URL used to fetch the feed"`
+}
+
+type dedupeFeedsArgs struct {
+	KeepFeedIDs []int64  `json:"keep_feed_ids" minimum:"1" description:"Feeds to keep instead of the suggested ones, at most one per duplicate group"`
+	KeepFeeds   []string `json:"keep_feeds" description:"Feeds to keep instead of the suggested ones, by ID, exact title, site URL or feed URL; an alternative to keep_feed_ids"`
+	Confirm     bool     `json:"confirm" default:"false" description:"Delete the duplicate feeds; without it only the planned deletions are returned"`
+}
+
+type feedHealthArgs struct {
+	categoryReference
+	StaleDays      int  `json:"stale_days" default:"30" minimum:"1" maximum:"3650" description:"Report feeds without a new entry for this many days as stale"`
+	IncludeHealthy bool `json:"include_healthy" default:"false" description:"Also list healthy feeds; they are always counted in the summaries"`
+}
+
+type feedEntriesArgs struct {
+	feedReference
+	Status    *string  `json:"status" enum:"read,unread,removed" description:"Filter by entry status"`
+	Limit     *int     `json:"limit" minimum:"1" description:"Limit the number of entries returned"`
+	Offset    *int     `json:"offset" minimum:"0" description:"Offset for pagination"`
+	Order     *string  `json:"order" enum:"id,status,changed_at,published_at,created_at,category_title,category_id,title,author" description:"Field used to sort entries"`
+	Direction *string  `json:"direction" enum:"asc,desc" description:"Sort direction"`
+	Cursor    *string  `json:"cursor" description:"The next_cursor of a previous call, to fetch the following page with the same filter"`
+	Format    string   `json:"format" enum:"json,markdown,text" default:"json" description:"Output format: raw JSON, or Markdown or plain text with entry content converted from HTML"`
+	MaxChars  *int     `json:"max_chars" minimum:"1" description:"Maximum number of characters in the response; longer results are truncated with a continuation hint"`
+	Fields    []string `json:"fields" description:"Return only these entry fields, using dots for nested fields (for example id, title, url, published_at, feed.title); JSON format only"`
+}
+
+type feedEntryArgs struct {
+	feedReference
+	EntryID  int64  `json:"entry_id" required:"true" minimum:"1" description:"The ID of the entry"`
+	Format   string `json:"format" enum:"json,markdown,text" default:"json" description:"Output format: raw JSON, or Markdown or plain text with entry content converted from HTML"`
+	MaxChars *int   `json:"max_chars" minimum:"1" description:"Maximum number of characters in the response; longer results are truncated with a continuation hint"`
+}
+
+// Entry Arguments
+
+// entryFilterArgs are the entry filters shared by get_entries and
+// update_entries_status.
+type entryFilterArgs struct {
+	Statuses []string `json:"statuses" enum:"read,unread,removed" description:"Filter by multiple entry statuses; takes precedence over status"`
+	feedReference
+	categoryReference
+	PublishedAfter  *int64  `json:"published_after" minimum:"0" description:"Return entries published after this Unix timestamp"`
+	PublishedBefore *int64  `json:"published_before" minimum:"0" description:"Return entries published before this Unix timestamp"`
+	ChangedAfter    *int64  `json:"changed_after" minimum:"0" description:"Return entries changed after this Unix timestamp"`
+	ChangedBefore   *int64  `json:"changed_before" minimum:"0" description:"Return entries changed before this Unix timestamp"`
+	BeforeEntryID   *int64  `json:"before_entry_id" minimum:"1" description:"Return entries with an ID lower than this value"`
+	AfterEntryID    *int64  `json:"after_entry_id" minimum:"1" description:"Return entries with an ID greater than this value"`
+	Search          *string `json:"search" description:"Search entry title and content"`
+	Starred         *bool   `json:"starred" description:"Filter by starred state"`
+	GloballyVisible *bool   `json:"globally_visible" description:"Restrict results to globally visible entries when true"`
+}
+
+type entriesArgs struct {
+	Status *string `json:"status" enum:"read,unread,removed" description:"Filter by entry status"`
+	entryFilterArgs
+	Limit     *int     `json:"limit" minimum:"1" description:"Limit the number of entries returned"`
+	Offset    int      `json:"offset" minimum:"0" default:"0" description:"Offset for pagination"`
+	Cursor    *string  `json:"cursor" description:"The next_cursor of a previous call, to fetch the following page with the same filter"`
+	Order     *string  `json:"order" enum:"id,status,changed_at,published_at,created_at,category_title,category_id,title,author" description:"Field used to sort entries"`
+	Direction *string  `json:"direction" enum:"asc,desc" description:"Sort direction"`
+	Format    string   `json:"format" enum:"json,markdown,text" default:"json" description:"Output format: raw JSON, or Markdown or plain text with entry content converted from HTML"`
+	MaxChars  *int     `json:"max_chars" minimum:"1" description:"Maximum number of characters in the response; longer results are truncated with a continuation hint"`
+	Fields    []string `json:"fields" description:"Return only these entry fields, using dots for nested fields (for example id, title, url, published_at, feed.title); JSON format only"`
+}
+
+type entryArgs struct {
+	EntryID int64 `json:"entry_id" required:"true" minimum:"1" description:"The ID of the entry"`
+}
+
+type getEntryArgs struct {
+	EntryID  int64  `json:"entry_id" required:"true" minimum:"1" description:"The ID of the entry"`
+	Format   string `json:"format" enum:"json,markdown,text" default:"json" description:"Output format: raw JSON, or Markdown or plain text with entry content converted from HTML"`
+	MaxChars *int   `json:"max_chars" minimum:"1" description:"Maximum number of characters in the response; longer results are truncated with a continuation hint"`
+}
+
+type updateEntryStatusArgs struct {
+	EntryID int64  `json:"entry_id" required:"true" minimum:"1" description:"The ID of the entry to update"`
+	Status  string `json:"status" required:"true" enum:"read,unread,removed" description:"New status for the entry"`
+}
+
+type updateEntryArgs struct {
+	EntryID int64   `json:"entry_id" required:"true" minimum:"1" description:"The ID of the entry to update"`
+	Title   *string `json:"title" description:"New entry title"`
+	Content *string `json:"content" description:"New entry content, as HTML"`
+}
+
+type setStarredArgs struct {
+	EntryIDs []int64 `json:"entry_ids" required:"true" minimum:"1" description:"The IDs of the entries to star or unstar"`
+	Starred  bool    `json:"starred" required:"true" description:"Whether the entries should be starred"`
+}
+
+type updateEntriesStatusArgs struct {
+	Status   string  `json:"status" required:"true" enum:"read,unread,removed" description:"New status for the entries"`
+	EntryIDs []int64 `json:"entry_ids" minimum:"1" description:"The IDs of the entries to update; use either this or the filter arguments"`
+	entryFilterArgs
+}
+
+// Category Arguments
+type categoryArgs struct {
+	categoryReference
+}
+
+type categoryFeedsArgs struct {
+	categoryReference
+	Fields []string `json:"fields" description:"Return only these feed fields, using dots for nested fields (for example id, title, feed_url, category.title)"`
+}
+
+type createCategoryArgs struct {
+	Title        string `json:"title" required:"true" description:"The title of the category"`
+	HideGlobally bool   `json:"hide_globally" default:"false" description:"Hide the entries of the category from the unread list"`
+}
+
+type updateCategoryArgs struct {
+	categoryReference
+	Title        *string `json:"title" description:"The new title of the category"`
+	HideGlobally *bool   `json:"hide_globally" description:"Hide the entries of the category from the unread list"`
+}
+
+type mergeCategoriesArgs struct {
+	SourceIDs []int64  `json:"source_ids" minimum:"1" description:"The IDs of the categories to merge into the target and then delete"`
+	Sources   []string `json:"sources" description:"The categories to merge into the target and then delete, by ID or exact title; an alternative to source_ids"`
+	TargetID  *int64   `json:"target_id" minimum:"1" description:"The ID of the category receiving the feeds"`
+	Target    *string  `json:"target" description:"The category receiving the feeds, by ID or exact title; an alternative to target_id"`
+}
+
+type categoryEntriesArgs struct {
+	categoryReference
+	Status    *string  `json:"status" enum:"read,unread,removed" description:"Filter by entry status"`
+	Limit     *int     `json:"limit" minimum:"1" description:"Limit the number of entries returned"`
+	Offset    *int     `json:"offset" minimum:"0" description:"Offset for pagination"`
+	Order     *string  `json:"order" enum:"id,status,changed_at,published_at,created_at,category_title,category_id,title,author" description:"Field used to sort entries"`
+	Direction *string  `json:"direction" enum:"asc,desc" description:"Sort direction"`
+	Cursor    *string  `json:"cursor" description:"The next_cursor of a previous call, to fetch the following page with the same filter"`
+	Format    string   `json:"format" enum:"json,markdown,text" default:"json" description:"Output format: raw JSON, or Markdown or plain text with entry content converted from HTML"`
+	MaxChars  *int     `json:"max_chars" minimum:"1" description:"Maximum number of characters in the response; longer results are truncated with a continuation hint"`
+	Fields    []string `json:"fields" description:"Return only these entry fields, using dots for nested fields (for example id, title, url, published_at, feed.title); JSON format only"`
+}
+
+type categoryEntryArgs struct {
+	categoryReference
+	EntryID  int64  `json:"entry_id" required:"true" minimum:"1" description:"The ID of the entry"`
+	Format   string `json:"format" enum:"json,markdown,text" default:"json" description:"Output format: raw JSON, or Markdown or plain text with entry content converted from HTML"`
+	MaxChars *int   `json:"max_chars" minimum:"1" description:"Maximum number of characters in the response; longer results are truncated with a continuation hint"`
+}
+
+// User Arguments
+type userArgs struct {
+	UserID int64 `json:"user_id" required:"true" minimum:"1" description:"The ID of the user"`
+}
+
+type usernameArgs struct {
+	Username string `json:"username" required:"true" description:"The username of the user"`
+}
+
+type createUserArgs struct {
+	Username string `json:"username" required:"true" description:"The username for the new user"`
+	Password string `json:"password" required:"true" description:"The password for the new user"`
+	IsAdmin  bool   `json:"is_admin" default:"false" description:"Whether the user should be an admin"`
+}
+
+type updateUserArgs struct {
+	UserID                  int64   `json:"user_id" required:"true" minimum:"1" description:"The ID of the user to update"`
+	Username                *string `json:"username" description:"New username"`
+	IsAdmin                 *bool   `json:"is_admin" description:"Whether the user is an admin"`
+	Theme                   *string `json:"theme" enum:"light_serif,light_sans_serif,dark_serif,dark_sans_serif,system_serif,system_sans_serif" description:"Theme of the web interface"`
+	Language                *string `json:"language" enum:"ar_SA,de_DE,el_EL,en_US,es_ES,fi_FI,fr_FR,gl_ES,hi_IN,id_ID,it_IT,ja_JP,ko_KR,nan_Latn_pehoeji,nl_NL,pl_PL,pt_BR,ro_RO,ru_RU,tr_TR,uk_UA,zh_CN,zh_TW" description:"Language of the web interface"`
+	Timezone                *string `json:"timezone" description:"IANA time zone, for example Europe/Paris"`
+	EntrySortingDirection   *string `json:"entry_sorting_direction" enum:"asc,desc" description:"Direction entries are sorted in"`
+	EntrySortingOrder       *string `json:"entry_sorting_order" enum:"published_at,created_at" description:"Date entries are sorted by"`
+	EntriesPerPage          *int    `json:"entries_per_page" minimum:"1" maximum:"1000" description:"Number of entries per page"`
+	KeyboardShortcuts       *bool   `json:"keyboard_shortcuts" description:"Enable keyboard shortcuts"`
+	ShowReadingTime         *bool   `json:"show_reading_time" description:"Show the estimated reading time of entries"`
+	MarkReadOnView          *bool   `json:"mark_read_on_view" description:"Mark entries as read when viewed"`
+	EntrySwipe              *bool   `json:"entry_swipe" description:"Enable swipe gestures on entries"`
+	GestureNav              *string `json:"gesture_nav" enum:"none,tap,swipe" description:"Gesture used to navigate between entries"`
+	DisplayMode             *string `json:"display_mode" enum:"fullscreen,standalone,minimal-ui,browser" description:"Display mode of the installed web app"`
+	DefaultHomePage         *string `json:"default_home_page" enum:"unread,starred,history,feeds,categories" description:"Page shown after login"`
+	CategoriesSortingOrder  *string `json:"categories_sorting_order" enum:"alphabetical,unread_count" description:"Order categories are listed in"`
+	DefaultReadingSpeed     *int    `json:"default_reading_speed" minimum:"1" description:"Reading speed in words per minute"`
+	CJKReadingSpeed         *int    `json:"cjk_reading_speed" minimum:"1" description:"Reading speed for Chinese, Japanese and Korean in characters per minute"`
+	Stylesheet              *string `json:"stylesheet" description:"Custom CSS of the web interface"`
+	CustomJS                *string `json:"custom_js" description:"Custom JavaScript of the web interface"`
+	ExternalFontHosts       *string `json:"external_font_hosts" description:"Space separated hosts allowed to serve fonts to the custom CSS"`
+	BlockFilterEntryRules   *string `json:"block_filter_entry_rules" description:"Entry blocking rules applied to all feeds, one per line"`
+	KeepFilterEntryRules    *string `json:"keep_filter_entry_rules" description:"Entry keep rules applied to all feeds, one per line"`
+	AlwaysOpenExternalLinks *bool   `json:"always_open_external_links" description:"Open entry links on the original site instead of in Miniflux"`
+	OpenExternalLinksNewTab *bool   `json:"open_external_links_in_new_tab" description:"Open external links in a new tab"`
+}
+
+// System Arguments
+type discoverArgs struct {
+	URL string `json:"url" required:"true" description:"The URL to discover feeds from"`
+}
+
+type importOPMLArgs struct {
+	OPML   string `json:"opml" required:"true" description:"The OPML document to import"`
+	DryRun bool   `json:"dry_run" default:"true" description:"Only preview the feeds and categories the import would create; set to false to import after reviewing the preview"`
+}
+
+// API Key Arguments
+type createAPIKeyArgs struct {
+	Description string `json:"description" required:"true" description:"Description for the API key"`
+}
+
+type apiKeyArgs struct {
+	APIKeyID int64 `json:"api_key_id" required:"true" minimum:"1" description:"The ID of the API key"`
+}
+
+// Icon and Enclosure Arguments
+type iconArgs struct {
+	IconID int64 `json:"icon_id" required:"true" minimum:"1" description:"The ID of the icon"`
+}
+
+type enclosureArgs struct {
+	EnclosureID int64 `json:"enclosure_id" required:"true" minimum:"1" description:"The ID of the enclosure"`
+}
@@ -0,0 +1,382 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Reasons reported in argumentError.Reason. They are part of the tool
+// contract: clients may branch on them, so existing values must not change.
+const (
+	reasonInvalidArguments = "invalid_arguments"
+	reasonUnknownArgument  = "unknown_argument"
+	reasonRequired         = "required"
+	reasonInvalidType      = "invalid_type"
+	reasonNotInteger       = "not_integer"
+	reasonOutOfRange       = "out_of_range"
+	reasonInvalidEnum      = "invalid_enum"
+	reasonUnknownField     = "unknown_field"
+	reasonInvalidCursor    = "invalid_cursor"
+	reasonInvalidOPML      = "invalid_opml"
+	// Reasons of feed and category references given by name.
+	reasonUnknownReference   = "unknown_reference"
+	reasonAmbiguousReference = "ambiguous_reference"
+)
+
+// argumentError describes why the arguments of a tool call were rejected.
+type argumentError struct {
+	Argument string   `json:"argument,omitempty"`
+	Reason   string   `json:"reason"`
+	Message  string   `json:"message"`
+	Accepted []string `json:"accepted,omitempty"`
+}
+
+func (e *argumentError) Error() string {
+	return e.Message
+}
+
+// argumentErrorResult converts a binding error into a tool error result. The
+// message is returned as text and the full argumentError as structured
+// content, so clients can react to the reason without parsing the message.
+func argumentErrorResult(err error) *mcp.CallToolResult {
+	result := mcp.NewToolResultError(err.Error())
+
+	var argErr *argumentError
+	if errors.As(err, &argErr) {
+		result.StructuredContent = map[string]any{"error": argErr}
+	}
+	return result
+}
+
+// argumentField describes one bindable field of a tool argument struct.
+//
+// Fields are declared with the following struct tags:
+//   - json: the argument name
+//   - required:"true": the argument must be present
+//   - enum:"a,b,c": allowed values for string and []string arguments
+//   - minimum, maximum: inclusive bounds for integer arguments
+//   - default: value bound when the argument is omitted
+//   - description: the argument description shown to clients
+//
+// Optional arguments without a default use pointer types so handlers can
+// tell an omitted argument from its zero value. The fields of embedded
+// structs are arguments of the embedding struct.
+type argumentField struct {
+	Name        string
+	Index       []int
+	Type        reflect.Type
+	Required    bool
+	Enum        []string
+	Minimum     *int64
+	Maximum     *int64
+	Default     any
+	Description string
+}
+
+func argumentFields(structType reflect.Type) ([]argumentField, error) {
+	fields := make([]argumentField, 0, structType.NumField())
+	for i := 0; i < structType.NumField(); i++ {
+		structField := structType.Field(i)
+		name, _, _ := strings.Cut(structField.Tag.Get("json"), ",")
+		if structField.Anonymous && structField.Type.Kind() == reflect.Struct && name == "" {
+			embedded, err := argumentFields(structField.Type)
+			if err != nil {
+				return nil, err
+			}
+			for _, field := range embedded {
+				field.Index = append([]int{i}, field.Index...)
+				fields = append(fields, field)
+			}
+			continue
+		}
+		if !structField.IsExported() || name == "" || name == "-" {
+			continue
+		}
+
+		field := argumentField{
+			Name:        name,
+			Index:       []int{i},
+			Type:        structField.Type,
+			Required:    structField.Tag.Get("required") == "true",
+			Description: structField.Tag.Get("description"),
+		}
+		if enum := structField.Tag.Get("enum"); enum != "" {
+			field.Enum = strings.Split(enum, ",")
+		}
+		for tag, target := range map[string]**int64{"minimum": &field.Minimum, "maximum": &field.Maximum} {
+			value, ok := structField.Tag.Lookup(tag)
+			if !ok {
+				continue
+			}
+			bound, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%s.%s: invalid %s tag %q", structType.Name(), structField.Name, tag, value)
+			}
+			*target = &bound
+		}
+		if value, ok := structField.Tag.Lookup("default"); ok {
+			defaultValue, err := parseDefault(structField.Type, value)
+			if err != nil {
+				return nil, fmt.Errorf("%s.%s: invalid default tag %q: %w", structType.Name(), structField.Name, value, err)
+			}
+			field.Default = defaultValue
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// parseDefault converts a default tag to the value a JSON client would send
+// for a field of the given type.
+func parseDefault(fieldType reflect.Type, value string) (any, error) {
+	if fieldType.Kind() == reflect.Pointer {
+		fieldType = fieldType.Elem()
+	}
+	switch fieldType.Kind() {
+	case reflect.String:
+		return value, nil
+	case reflect.Bool:
+		return strconv.ParseBool(value)
+	case reflect.Int, reflect.Int64:
+		return strconv.ParseInt(value, 10, 64)
+	default:
+		return nil, fmt.Errorf("defaults are not supported for %s", fieldType)
+	}
+}
+
+// bindArguments decodes the arguments of a tool call into target, which must
+// be a pointer to a struct whose fields follow the argumentField conventions.
+// Unknown arguments are rejected so typos do not silently fall back to
+// defaults. Every validation failure is reported as an *argumentError.
+func bindArguments(request mcp.CallToolRequest, target any) error {
+	targetValue := reflect.ValueOf(target)
+	if targetValue.Kind() != reflect.Pointer || targetValue.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("bind target must be a pointer to a struct, got %T", target)
+	}
+	structValue := targetValue.Elem()
+
+	fields, err := argumentFields(structValue.Type())
+	if err != nil {
+		return err
+	}
+
+	values, err := argumentValues(request)
+	if err != nil {
+		return err
+	}
+
+	accepted := make([]string, 0, len(fields))
+	for _, field := range fields {
+		accepted = append(accepted, field.Name)
+	}
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		if !slices.Contains(accepted, name) {
+			return &argumentError{
+				Argument: name,
+				Reason:   reasonUnknownArgument,
+				Message:  fmt.Sprintf("unknown argument %q", name),
+				Accepted: accepted,
+			}
+		}
+	}
+
+	for _, field := range fields {
+		value := values[field.Name]
+		if value == nil {
+			value = field.Default
+		}
+		if value == nil {
+			if field.Required {
+				return &argumentError{
+					Argument: field.Name,
+					Reason:   reasonRequired,
+					Message:  fmt.Sprintf("%s is required", field.Name),
+				}
+			}
+			continue
+		}
+		if err := field.assign(structValue.FieldByIndex(field.Index), value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// argumentValues returns the raw argument object of a tool call. Arguments
+// received over the wire are re-decoded from their original JSON so large
+// integers do not lose precision through float64.
+func argumentValues(request mcp.CallToolRequest) (map[string]any, error) {
+	invalid := &argumentError{
+		Reason:  reasonInvalidArguments,
+		Message: "arguments must be a JSON object",
+	}
+
+	if len(request.Params.RawArguments) > 0 {
+		decoder := json.NewDecoder(bytes.NewReader(request.Params.RawArguments))
+		decoder.UseNumber()
+		var values map[string]any
+		if err := decoder.Decode(&values); err != nil {
+			return nil, invalid
+		}
+		return values, nil
+	}
+
+	switch args := request.Params.Arguments.(type) {
+	case nil:
+		return nil, nil
+	case map[string]any:
+		return args, nil
+	default:
+		return nil, invalid
+	}
+}
+
+func (f argumentField) assign(target reflect.Value, value any) error {
+	if target.Kind() == reflect.Pointer {
+		element := reflect.New(target.Type().Elem())
+		if err := f.assign(element.Elem(), value); err != nil {
+			return err
+		}
+		target.Set(element)
+		return nil
+	}
+
+	switch target.Kind() {
+	case reflect.String:
+		text, ok := value.(string)
+		if !ok {
+			return f.typeError("a string")
+		}
+		if err := f.checkEnum(text); err != nil {
+			return err
+		}
+		target.SetString(text)
+	case reflect.Bool:
+		flag, ok := value.(bool)
+		if !ok {
+			return f.typeError("a boolean")
+		}
+		target.SetBool(flag)
+	case reflect.Int, reflect.Int64:
+		number, err := f.integer(value)
+		if err != nil {
+			return err
+		}
+		if target.OverflowInt(number) {
+			return f.rangeError()
+		}
+		target.SetInt(number)
+	case reflect.Slice:
+		items, ok := value.([]any)
+		if !ok {
+			return f.typeError("an array")
+		}
+		slice := reflect.MakeSlice(target.Type(), len(items), len(items))
+		for i, item := range items {
+			if item == nil {
+				return f.typeError("an array without null items")
+			}
+			if err := f.assign(slice.Index(i), item); err != nil {
+				return err
+			}
+		}
+		target.Set(slice)
+	default:
+		return fmt.Errorf("argument %s has unsupported type %s", f.Name, target.Type())
+	}
+	return nil
+}
+
+// integer converts a JSON number to int64, rejecting fractional values and
+// values outside the int64 range or the field's declared bounds.
+func (f argumentField) integer(value any) (int64, error) {
+	var number int64
+	switch typed := value.(type) {
+	case json.Number:
+		parsed, err := typed.Int64()
+		if err != nil {
+			asFloat, floatErr := typed.Float64()
+			if floatErr != nil {
+				return 0, f.typeError("an integer")
+			}
+			return f.integer(asFloat)
+		}
+		number = parsed
+	case float64:
+		if math.IsNaN(typed) || math.IsInf(typed, 0) || typed != math.Trunc(typed) {
+			return 0, &argumentError{
+				Argument: f.Name,
+				Reason:   reasonNotInteger,
+				Message:  fmt.Sprintf("%s must be an integer", f.Name),
+			}
+		}
+		if typed < math.MinInt64 || typed >= math.MaxInt64 {
+			return 0, f.rangeError()
+		}
+		number = int64(typed)
+	case int:
+		number = int64(typed)
+	case int64:
+		number = typed
+	default:
+		return 0, f.typeError("an integer")
+	}
+
+	if (f.Minimum != nil && number < *f.Minimum) || (f.Maximum != nil && number > *f.Maximum) {
+		return 0, f.rangeError()
+	}
+	return number, nil
+}
+
+func (f argumentField) checkEnum(value string) error {
+	if len(f.Enum) == 0 || slices.Contains(f.Enum, value) {
+		return nil
+	}
+	return &argumentError{
+		Argument: f.Name,
+		Reason:   reasonInvalidEnum,
+		Message:  fmt.Sprintf("%s must be one of: %s", f.Name, strings.Join(f.Enum, ", ")),
+		Accepted: f.Enum,
+	}
+}
+
+func (f argumentField) typeError(expected string) error {
+	return &argumentError{
+		Argument: f.Name,
+		Reason:   reasonInvalidType,
+		Message:  fmt.Sprintf("%s must be %s", f.Name, expected),
+	}
+}
+
+func (f argumentField) rangeError() error {
+	var message string
+	switch {
+	case f.Minimum != nil && f.Maximum != nil:
+		message = fmt.Sprintf("%s must be between %d and %d", f.Name, *f.Minimum, *f.Maximum)
+	case f.Minimum != nil:
+		message = fmt.Sprintf("%s must be at least %d", f.Name, *f.Minimum)
+	case f.Maximum != nil:
+		message = fmt.Sprintf("%s must be at most %d", f.Name, *f.Maximum)
+	default:
+		message = fmt.Sprintf("%s is out of range", f.Name)
+	}
+	return &argumentError{
+		Argument: f.Name,
+		Reason:   reasonOutOfRange,
+		Message:  message,
+	}
+}
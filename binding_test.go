@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+type bindingTestArgs struct {
+	ID       int64    `json:"id" required:"true" minimum:"1"`
+	Limit    *int     `json:"limit" minimum:"1" maximum:"100"`
+	Status   *string  `json:"status" enum:"read,unread"`
+	Statuses []string `json:"statuses" enum:"read,unread"`
+	Starred  *bool    `json:"starred"`
+}
+
+func TestBindArguments(t *testing.T) {
+	request := mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Arguments: map[string]interface{}{
+				"id":       float64(42),
+				"limit":    float64(10),
+				"status":   "unread",
+				"statuses": []interface{}{"read", "unread"},
+				"starred":  false,
+			},
+		},
+	}
+
+	var args bindingTestArgs
+	if err := bindArguments(request, &args); err != nil {
+		t.Fatalf("bindArguments returned error: %v", err)
+	}
+	if args.ID != 42 {
+		t.Errorf("id = %d, want 42", args.ID)
+	}
+	if args.Limit == nil || *args.Limit != 10 {
+		t.Errorf("limit = %v, want 10", args.Limit)
+	}
+	if args.Status == nil || *args.Status != "unread" {
+		t.Errorf("status = %v, want unread", args.Status)
+	}
+	if len(args.Statuses) != 2 {
+		t.Errorf("statuses = %#v, want read and unread", args.Statuses)
+	}
+	if args.Starred == nil || *args.Starred {
+		t.Errorf("starred = %v, want explicit false", args.Starred)
+	}
+}
+
+func TestBindArgumentsKeepsLargeIntegersFromRawArguments(t *testing.T) {
+	var params mcp.CallToolParams
+	if err := json.Unmarshal([]byte(`{"name":"test","arguments":{"id":9007199254740993}}`), &params); err != nil {
+		t.Fatalf("decode params: %v", err)
+	}
+
+	var args bindingTestArgs
+	if err := bindArguments(mcp.CallToolRequest{Params: params}, &args); err != nil {
+		t.Fatalf("bindArguments returned error: %v", err)
+	}
+	if args.ID != 9007199254740993 {
+		t.Errorf("id = %d, want 9007199254740993", args.ID)
+	}
+}
+
+func TestBindArgumentsRejectsInvalidArguments(t *testing.T) {
+	testCases := []struct {
+		name      string
+		arguments any
+		argument  string
+		reason    string
+	}{
+		{"not an object", "feed", "", reasonInvalidArguments},
+		{"missing required", map[string]any{}, "id", reasonRequired},
+		{"null required", map[string]any{"id": nil}, "id", reasonRequired},
+		{"fractional id", map[string]any{"id": 3.7}, "id", reasonNotInteger},
+		{"string id", map[string]any{"id": "42"}, "id", reasonInvalidType},
+		{"below minimum", map[string]any{"id": float64(0)}, "id", reasonOutOfRange},
+		{"above maximum", map[string]any{"id": float64(1), "limit": float64(101)}, "limit", reasonOutOfRange},
+		{"beyond int64", map[string]any{"id": 1e19}, "id", reasonOutOfRange},
+		{"invalid enum", map[string]any{"id": float64(1), "status": "removed"}, "status", reasonInvalidEnum},
+		{"invalid enum item", map[string]any{"id": float64(1), "statuses": []any{"read", "starred"}}, "statuses", reasonInvalidEnum},
+		{"wrong item type", map[string]any{"id": float64(1), "statuses": []any{float64(1)}}, "statuses", reasonInvalidType},
+		{"wrong boolean type", map[string]any{"id": float64(1), "starred": "yes"}, "starred", reasonInvalidType},
+		{"unknown argument", map[string]any{"id": float64(1), "feed": float64(1)}, "feed", reasonUnknownArgument},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			request := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: testCase.arguments}}
+
+			var args bindingTestArgs
+			err := bindArguments(request, &args)
+			var argErr *argumentError
+			if !errors.As(err, &argErr) {
+				t.Fatalf("bindArguments error = %v, want an argument error", err)
+			}
+			if argErr.Argument != testCase.argument || argErr.Reason != testCase.reason {
+				t.Errorf("error = %+v, want argument %q with reason %q", argErr, testCase.argument, testCase.reason)
+			}
+		})
+	}
+}
+
+func TestHandlersReportArgumentErrors(t *testing.T) {
+	minifluxServer := &MinifluxServer{}
+	request := mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Arguments: map[string]interface{}{
+				"feed_id": 3.7,
+			},
+		},
+	}
+
+	result, err := minifluxServer.GetFeed(context.Background(), request)
+	if err != nil {
+		t.Fatalf("GetFeed returned error: %v", err)
+	}
+	if !result.IsError {
+		t.Fatal("GetFeed accepted a fractional feed_id")
+	}
+
+	structured, ok := result.StructuredContent.(map[string]any)
+	if !ok {
+		t.Fatalf("structured content = %#v, want an error object", result.StructuredContent)
+	}
+	argErr, ok := structured["error"].(*argumentError)
+	if !ok || argErr.Argument != "feed_id" || argErr.Reason != reasonNotInteger {
+		t.Errorf("structured error = %#v, want feed_id %s", structured["error"], reasonNotInteger)
+	}
+}
@@ -0,0 +1,359 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"miniflux.app/v2/client"
+)
+
+const (
+	// entryStatusChunkSize is the number of entries updated per request.
+	entryStatusChunkSize = 100
+	// bulkPageSize is the number of entries fetched per request while
+	// collecting the entries matched by a filter.
+	bulkPageSize = 250
+	// maxBulkEntries bounds the entries a filter may select, so a missing
+	// filter argument cannot silently change the whole history.
+	maxBulkEntries = 5000
+)
+
+// entryStatusUpdate is the result of update_entries_status.
+type entryStatusUpdate struct {
+	Status  string             `json:"status"`
+	Matched int                `json:"matched"`
+	Updated int                `json:"updated"`
+	Failed  int                `json:"failed"`
+	Chunks  []entryStatusChunk `json:"chunks"`
+}
+
+// entryStatusChunk reports one request of a bulk status update.
+type entryStatusChunk struct {
+	EntryIDs []int64 `json:"entry_ids"`
+	Updated  bool    `json:"updated"`
+	Error    string  `json:"error,omitempty"`
+}
+
+func (s *MinifluxServer) UpdateEntriesStatus(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args updateEntriesStatusArgs
+	if err := bindArguments(request, &args); err != nil {
+		return argumentErrorResult(err), nil
+	}
+
+	filtered := !reflect.ValueOf(args.entryFilterArgs).IsZero()
+	var entryIDs []int64
+	switch {
+	case args.EntryIDs != nil && filtered:
+		return argumentErrorResult(&argumentError{
+			Argument: "entry_ids",
+			Reason:   reasonInvalidArguments,
+			Message:  "use either entry_ids or filter arguments, not both",
+		}), nil
+	case len(args.EntryIDs) > 0:
+		entryIDs = uniqueIDs(args.EntryIDs)
+	case filtered:
+		filter, err := s.entryFilter(args.entryFilterArgs)
+		if err != nil {
+			return argumentErrorResult(err), nil
+		}
+		if !restrictsEntries(filter) {
+			return argumentErrorResult(&argumentError{
+				Argument: "entry_ids",
+				Reason:   reasonInvalidArguments,
+				Message:  "the filter arguments select every entry: an empty statuses list, globally_visible false or a zero timestamp do not restrict anything; add a filter that does or use entry_ids",
+			}), nil
+		}
+		if entryIDs, err = s.matchingEntryIDs(filter); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to fetch entries: %v", err)), nil
+		}
+	default:
+		return argumentErrorResult(&argumentError{
+			Argument: "entry_ids",
+			Reason:   reasonRequired,
+			Message:  "a non-empty entry_ids or at least one filter argument is required",
+		}), nil
+	}
+
+	update := entryStatusUpdate{Status: args.Status, Matched: len(entryIDs), Chunks: []entryStatusChunk{}}
+	for chunk := range slices.Chunk(entryIDs, entryStatusChunkSize) {
+		result := entryStatusChunk{EntryIDs: chunk}
+		if err := ctx.Err(); err != nil {
+			result.Error = err.Error()
+		} else if err := s.client.UpdateEntries(chunk, args.Status); err != nil {
+			result.Error = err.Error()
+		} else {
+			result.Updated = true
+		}
+		if result.Updated {
+			update.Updated += len(chunk)
+		} else {
+			update.Failed += len(chunk)
+		}
+		update.Chunks = append(update.Chunks, result)
+	}
+
+	updateJSON, err := json.MarshalIndent(update, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal status update: %v", err)), nil
+	}
+	result := mcp.NewToolResultStructured(update, string(updateJSON))
+	result.IsError = update.Failed > 0
+	return result, nil
+}
+
+// restrictsEntries reports whether filter excludes any entry. Filter
+// arguments may be given yet restrict nothing, such as an empty statuses
+// list, so bulk updates check the built filter rather than the arguments.
+func restrictsEntries(filter *client.Filter) bool {
+	return len(filter.Statuses) > 0 || filter.Status != "" ||
+		filter.FeedID > 0 || filter.CategoryID > 0 ||
+		filter.PublishedAfter > 0 || filter.PublishedBefore > 0 ||
+		filter.ChangedAfter > 0 || filter.ChangedBefore > 0 ||
+		filter.BeforeEntryID > 0 || filter.AfterEntryID > 0 ||
+		filter.Search != "" || filter.Starred != "" || filter.GloballyVisible
+}
+
+// matchingEntryIDs returns the IDs of every entry matched by filter. All the
+// IDs are collected before any status changes, since updating the entries
+// could otherwise move them in or out of a status filter between pages.
+func (s *MinifluxServer) matchingEntryIDs(filter *client.Filter) ([]int64, error) {
+	page := *filter
+	page.Order, page.Direction, page.Limit = "id", "asc", bulkPageSize
+
+	var entryIDs []int64
+	for {
+		entries, err := s.client.Entries(&page)
+		if err != nil {
+			return nil, err
+		}
+		if len(entryIDs) == 0 && entries.Total > maxBulkEntries {
+			return nil, fmt.Errorf("the filter matches %d entries, more than the %d that can be updated at once; narrow it down", entries.Total, maxBulkEntries)
+		}
+		for _, entry := range entries.Entries {
+			entryIDs = append(entryIDs, entry.ID)
+		}
+		if len(entries.Entries) < bulkPageSize || len(entryIDs) >= maxBulkEntries {
+			return entryIDs, nil
+		}
+		page.AfterEntryID = entryIDs[len(entryIDs)-1]
+	}
+}
+
+// uniqueIDs returns ids without duplicates, in their original order.
+func uniqueIDs(ids []int64) []int64 {
+	seen := make(map[int64]bool, len(ids))
+	unique := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
+// starredUpdate is the result of set_starred.
+type starredUpdate struct {
+	Starred   bool                 `json:"starred"`
+	Changed   int                  `json:"changed"`
+	Unchanged int                  `json:"unchanged"`
+	Failed    int                  `json:"failed"`
+	Entries   []entryStarredResult `json:"entries"`
+}
+
+// entryStarredResult reports the starred state of one entry after set_starred.
+type entryStarredResult struct {
+	EntryID int64  `json:"entry_id"`
+	Starred bool   `json:"starred"`
+	Changed bool   `json:"changed"`
+	Error   string `json:"error,omitempty"`
+}
+
+// SetStarred reads the current state of each entry and only toggles the
+// entries whose state differs, since Miniflux only offers a toggle.
+func (s *MinifluxServer) SetStarred(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args setStarredArgs
+	if err := bindArguments(request, &args); err != nil {
+		return argumentErrorResult(err), nil
+	}
+
+	update := starredUpdate{Starred: args.Starred, Entries: []entryStarredResult{}}
+	for _, entryID := range uniqueIDs(args.EntryIDs) {
+		result := s.setEntryStarred(ctx, entryID, args.Starred)
+		switch {
+		case result.Error != "":
+			update.Failed++
+		case result.Changed:
+			update.Changed++
+		default:
+			update.Unchanged++
+		}
+		update.Entries = append(update.Entries, result)
+	}
+
+	updateJSON, err := json.MarshalIndent(update, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal starred update: %v", err)), nil
+	}
+	result := mcp.NewToolResultStructured(update, string(updateJSON))
+	result.IsError = update.Failed > 0
+	return result, nil
+}
+
+func (s *MinifluxServer) setEntryStarred(ctx context.Context, entryID int64, starred bool) entryStarredResult {
+	result := entryStarredResult{EntryID: entryID}
+	if err := ctx.Err(); err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	entry, err := s.client.Entry(entryID)
+	if err != nil {
+		result.Error = fmt.Sprintf("failed to fetch entry: %v", err)
+		return result
+	}
+	result.Starred = entry.Starred
+	if entry.Starred == starred {
+		return result
+	}
+	if err := s.client.ToggleStarred(entryID); err != nil {
+		result.Error = fmt.Sprintf("failed to toggle starred status: %v", err)
+		return result
+	}
+	result.Starred, result.Changed = starred, true
+	return result
+}
+
+// categoryMerge is the result of merge_categories.
+type categoryMerge struct {
+	TargetID int64                 `json:"target_id"`
+	Moves    []feedMove            `json:"moves"`
+	Sources  []mergedCategoryState `json:"sources"`
+}
+
+// feedMove reports the move of one feed to another category.
+type feedMove struct {
+	FeedID         int64  `json:"feed_id"`
+	Title          string `json:"title"`
+	FromCategoryID int64  `json:"from_category_id"`
+	Moved          bool   `json:"moved"`
+	Error          string `json:"error,omitempty"`
+}
+
+// mergedCategoryState reports whether a source category of a merge was
+// deleted. A category is only deleted once all its feeds were moved.
+type mergedCategoryState struct {
+	CategoryID int64  `json:"category_id"`
+	Title      string `json:"title"`
+	Deleted    bool   `json:"deleted"`
+	Error      string `json:"error,omitempty"`
+}
+
+func (s *MinifluxServer) MergeCategories(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args mergeCategoriesArgs
+	if err := bindArguments(request, &args); err != nil {
+		return argumentErrorResult(err), nil
+	}
+
+	categoryMatches := sync.OnceValues(s.categoryMatches)
+	targetArgument, sourceArgument := "target_id", "source_ids"
+	if args.Target != nil {
+		targetArgument = "target"
+	}
+	targetID, err := resolveReferenceArguments("category", "target_id", "target", args.TargetID, args.Target, categoryMatches)
+	if err != nil {
+		return argumentErrorResult(err), nil
+	}
+	sourceIDs := args.SourceIDs
+	switch {
+	case args.SourceIDs != nil && args.Sources != nil:
+		return argumentErrorResult(&argumentError{Argument: "sources", Reason: reasonInvalidArguments, Message: "use either source_ids or sources, not both"}), nil
+	case args.Sources != nil:
+		sourceArgument = "sources"
+		if sourceIDs, err = resolveReferences("category", "source_ids", sourceArgument, args.Sources, categoryMatches); err != nil {
+			return argumentErrorResult(err), nil
+		}
+	}
+	sourceIDs = uniqueIDs(sourceIDs)
+	if len(sourceIDs) == 0 {
+		return argumentErrorResult(&argumentError{Argument: sourceArgument, Reason: reasonRequired, Message: "source_ids or sources must list at least one category"}), nil
+	}
+	if slices.Contains(sourceIDs, targetID) {
+		return argumentErrorResult(&argumentError{Argument: sourceArgument, Reason: reasonInvalidArguments, Message: "the source categories must not contain the target"}), nil
+	}
+
+	// The categories listed to resolve references also validate the IDs.
+	categories, err := categoryMatches()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to fetch categories: %v", err)), nil
+	}
+	titles := make(map[int64]string, len(categories))
+	for _, category := range categories {
+		titles[category.ID] = category.Labels[0]
+	}
+	for _, categoryID := range append([]int64{targetID}, sourceIDs...) {
+		if _, ok := titles[categoryID]; !ok {
+			argument := sourceArgument
+			if categoryID == targetID {
+				argument = targetArgument
+			}
+			return argumentErrorResult(&argumentError{Argument: argument, Reason: reasonUnknownReference, Message: fmt.Sprintf("no category has the ID %d", categoryID)}), nil
+		}
+	}
+
+	merge := categoryMerge{TargetID: targetID, Moves: []feedMove{}, Sources: []mergedCategoryState{}}
+	failed := false
+	for _, sourceID := range sourceIDs {
+		state := mergedCategoryState{CategoryID: sourceID, Title: titles[sourceID]}
+		moved := s.moveCategoryFeeds(ctx, sourceID, targetID, &merge, &state)
+		if moved {
+			if err := s.client.DeleteCategory(sourceID); err != nil {
+				state.Error = fmt.Sprintf("failed to delete category: %v", err)
+			} else {
+				state.Deleted = true
+			}
+		}
+		failed = failed || !state.Deleted
+		merge.Sources = append(merge.Sources, state)
+	}
+
+	mergeJSON, err := json.MarshalIndent(merge, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal category merge: %v", err)), nil
+	}
+	result := mcp.NewToolResultStructured(merge, string(mergeJSON))
+	result.IsError = failed
+	return result, nil
+}
+
+// moveCategoryFeeds moves every feed of a category to the target category,
+// recording each move, and reports whether all of them succeeded.
+func (s *MinifluxServer) moveCategoryFeeds(ctx context.Context, sourceID, targetID int64, merge *categoryMerge, state *mergedCategoryState) bool {
+	feeds, err := s.client.CategoryFeeds(sourceID)
+	if err != nil {
+		state.Error = fmt.Sprintf("failed to fetch category feeds: %v", err)
+		return false
+	}
+
+	moved := true
+	for _, feed := range feeds {
+		move := feedMove{FeedID: feed.ID, Title: feed.Title, FromCategoryID: sourceID}
+		if err := ctx.Err(); err != nil {
+			move.Error = err.Error()
+		} else if _, err := s.client.UpdateFeed(feed.ID, &client.FeedModificationRequest{CategoryID: &targetID}); err != nil {
+			move.Error = err.Error()
+		} else {
+			move.Moved = true
+		}
+		moved = moved && move.Moved
+		merge.Moves = append(merge.Moves, move)
+	}
+	if !moved {
+		state.Error = "some feeds could not be moved, so the category was kept"
+	}
+	return moved
+}
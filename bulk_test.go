@@ -0,0 +1,270 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"miniflux.app/v2/client"
+)
+
+// fakeStatusUpdates records the bulk status updates sent to Miniflux and
+// fails the requests that include failingID.
+type fakeStatusUpdates struct {
+	mu        sync.Mutex
+	store     *fakeEntryStore
+	failingID int64
+	requests  [][]int64
+	status    string
+}
+
+func (f *fakeStatusUpdates) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		f.store.ServeHTTP(w, r)
+		return
+	}
+
+	var body struct {
+		EntryIDs []int64 `json:"entry_ids"`
+		Status   string  `json:"status"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	f.mu.Lock()
+	f.requests = append(f.requests, body.EntryIDs)
+	f.status = body.Status
+	f.mu.Unlock()
+	if slices.Contains(body.EntryIDs, f.failingID) {
+		http.Error(w, `{"error_message":"database error"}`, http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func callUpdateEntriesStatus(t *testing.T, minifluxServer *MinifluxServer, arguments map[string]any) *mcp.CallToolResult {
+	t.Helper()
+	result, err := minifluxServer.UpdateEntriesStatus(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: arguments}})
+	if err != nil {
+		t.Fatalf("UpdateEntriesStatus returned error: %v", err)
+	}
+	return result
+}
+
+func TestUpdateEntriesStatusByIDs(t *testing.T) {
+	updates := &fakeStatusUpdates{store: newFakeEntryStore(0)}
+	apiServer := httptest.NewServer(updates)
+	defer apiServer.Close()
+	minifluxServer := &MinifluxServer{client: client.NewClient(apiServer.URL, "test-api-key")}
+
+	ids := make([]any, 0, 251)
+	for id := 1; id <= 250; id++ {
+		ids = append(ids, float64(id))
+	}
+	ids = append(ids, float64(1))
+
+	result := callUpdateEntriesStatus(t, minifluxServer, map[string]any{"status": "read", "entry_ids": ids})
+	update := result.StructuredContent.(entryStatusUpdate)
+	if result.IsError || update.Matched != 250 || update.Updated != 250 || len(update.Chunks) != 3 {
+		t.Fatalf("update = %+v, want 250 entries updated in 3 chunks", update)
+	}
+	if len(updates.requests) != 3 || len(updates.requests[0]) != entryStatusChunkSize || len(updates.requests[2]) != 50 || updates.status != "read" {
+		t.Errorf("requests = %d with status %q, want chunks of 100, 100 and 50", len(updates.requests), updates.status)
+	}
+}
+
+func TestUpdateEntriesStatusByFilter(t *testing.T) {
+	updates := &fakeStatusUpdates{store: newFakeEntryStore(600), failingID: 301}
+	apiServer := httptest.NewServer(updates)
+	defer apiServer.Close()
+	minifluxServer := &MinifluxServer{client: client.NewClient(apiServer.URL, "test-api-key")}
+
+	result := callUpdateEntriesStatus(t, minifluxServer, map[string]any{"status": "removed", "after_entry_id": float64(100)})
+	update := result.StructuredContent.(entryStatusUpdate)
+	if update.Matched != 500 || update.Updated != 400 || update.Failed != 100 || len(update.Chunks) != 5 {
+		t.Fatalf("update = matched %d, updated %d, failed %d in %d chunks", update.Matched, update.Updated, update.Failed, len(update.Chunks))
+	}
+	if !result.IsError {
+		t.Error("a failed chunk is not reported as an error")
+	}
+	failed := update.Chunks[2]
+	if failed.Updated || failed.Error == "" || failed.EntryIDs[0] != 301 || !update.Chunks[3].Updated {
+		t.Errorf("third chunk = %+v, want a failure for entries 301 to 400 and later chunks applied", failed)
+	}
+}
+
+func TestUpdateEntriesStatusRejectsAmbiguousSelection(t *testing.T) {
+	minifluxServer := &MinifluxServer{}
+	testCases := []struct {
+		name      string
+		arguments map[string]any
+		reason    string
+	}{
+		{"neither", map[string]any{"status": "read"}, reasonRequired},
+		{"empty entry_ids", map[string]any{"status": "read", "entry_ids": []any{}}, reasonRequired},
+		{"both", map[string]any{"status": "read", "entry_ids": []any{float64(1)}, "starred": true}, reasonInvalidArguments},
+		{"empty statuses", map[string]any{"status": "removed", "statuses": []any{}}, reasonInvalidArguments},
+		{"not globally visible", map[string]any{"status": "removed", "globally_visible": false}, reasonInvalidArguments},
+		{"zero timestamp", map[string]any{"status": "removed", "published_after": float64(0)}, reasonInvalidArguments},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			result := callUpdateEntriesStatus(t, minifluxServer, testCase.arguments)
+			structured, _ := result.StructuredContent.(map[string]any)
+			argErr, ok := structured["error"].(*argumentError)
+			if !result.IsError || !ok || argErr.Reason != testCase.reason {
+				t.Errorf("result = %#v, want a %s argument error", result, testCase.reason)
+			}
+		})
+	}
+
+	var argErr *argumentError
+	err := bindArguments(mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"status": "read", "entry_ids": []any{float64(0)}}}}, &updateEntriesStatusArgs{})
+	if !errors.As(err, &argErr) || argErr.Reason != reasonOutOfRange {
+		t.Errorf("entry ID 0 error = %v, want out of range", err)
+	}
+}
+
+func TestSetStarredOnlyTogglesChangedEntries(t *testing.T) {
+	var mu sync.Mutex
+	starred := map[int64]bool{1: true, 2: false}
+	var toggled []int64
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		entryID, _ := strconv.ParseInt(strings.TrimPrefix(r.URL.Path, "/v1/entries/"), 10, 64)
+		_, exists := starred[entryID]
+		switch {
+		case r.Method == http.MethodGet && exists:
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(client.Entry{ID: entryID, Starred: starred[entryID]})
+		case r.Method == http.MethodPut && r.URL.Path == "/v1/entries/2/star":
+			toggled = append(toggled, 2)
+			starred[2] = !starred[2]
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer apiServer.Close()
+	minifluxServer := &MinifluxServer{client: client.NewClient(apiServer.URL, "test-api-key")}
+
+	arguments := map[string]any{"entry_ids": []any{float64(1), float64(2), float64(3)}, "starred": true}
+	for range 2 {
+		result, err := minifluxServer.SetStarred(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: arguments}})
+		if err != nil {
+			t.Fatalf("SetStarred returned error: %v", err)
+		}
+		update := result.StructuredContent.(starredUpdate)
+		if !result.IsError || update.Failed != 1 || update.Entries[2].Error == "" {
+			t.Errorf("update = %+v, want the missing entry 3 reported as failed", update)
+		}
+		if !update.Entries[0].Starred || !update.Entries[1].Starred {
+			t.Errorf("entries = %+v, want entries 1 and 2 starred", update.Entries)
+		}
+	}
+	if !slices.Equal(toggled, []int64{2}) {
+		t.Errorf("toggled entries = %v, want only entry 2 once", toggled)
+	}
+}
+
+func TestMergeCategories(t *testing.T) {
+	var mu sync.Mutex
+	moved := map[int64]int64{}
+	var deleted []string
+	categoryRequests := 0
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		var response any
+		switch {
+		case r.URL.Path == "/v1/categories":
+			categoryRequests++
+			response = client.Categories{{ID: 1, Title: "Tech"}, {ID: 2, Title: "Programming"}, {ID: 3, Title: "Golang"}}
+		case r.URL.Path == "/v1/categories/2/feeds":
+			response = client.Feeds{{ID: 10, Title: "Lobsters"}, {ID: 11, Title: "Hacker News"}}
+		case r.URL.Path == "/v1/categories/3/feeds":
+			response = client.Feeds{{ID: 12, Title: "Go Blog"}}
+		case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/v1/feeds/"):
+			feedID, _ := strconv.ParseInt(strings.TrimPrefix(r.URL.Path, "/v1/feeds/"), 10, 64)
+			if feedID == 12 {
+				http.Error(w, `{"error_message":"database error"}`, http.StatusInternalServerError)
+				return
+			}
+			var body client.FeedModificationRequest
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			moved[feedID] = *body.CategoryID
+			w.WriteHeader(http.StatusCreated)
+			response = client.Feed{ID: feedID}
+		case r.Method == http.MethodDelete:
+			deleted = append(deleted, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	defer apiServer.Close()
+	minifluxServer := &MinifluxServer{client: client.NewClient(apiServer.URL, "test-api-key")}
+
+	call := func(arguments map[string]any) *mcp.CallToolResult {
+		result, err := minifluxServer.MergeCategories(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: arguments}})
+		if err != nil {
+			t.Fatalf("MergeCategories returned error: %v", err)
+		}
+		return result
+	}
+
+	result := call(map[string]any{"source_ids": []any{float64(2), float64(3)}, "target_id": float64(1)})
+	merge := result.StructuredContent.(categoryMerge)
+	if !result.IsError {
+		t.Error("a failed move is not reported as an error")
+	}
+	if moved[10] != 1 || moved[11] != 1 || len(merge.Moves) != 3 || merge.Moves[2].Moved || merge.Moves[2].Error == "" {
+		t.Errorf("moves = %+v, want feeds 10 and 11 moved to 1 and feed 12 failed", merge.Moves)
+	}
+	if !slices.Equal(deleted, []string{"/v1/categories/2"}) || !merge.Sources[0].Deleted || merge.Sources[1].Deleted {
+		t.Errorf("deleted = %v, sources = %+v, want only the emptied category 2 deleted", deleted, merge.Sources)
+	}
+
+	for _, arguments := range []map[string]any{
+		{"source_ids": []any{float64(1)}, "target_id": float64(1)},
+		{"source_ids": []any{float64(2)}, "target_id": float64(9)},
+		{"sources": []any{"golang"}, "target": "Golang"},
+		{"sources": []any{"Rust"}, "target_id": float64(1)},
+		{"source_ids": []any{float64(2)}, "sources": []any{"Programming"}, "target": "Tech"},
+		{"sources": []any{"Programming"}},
+	} {
+		if result := call(arguments); !result.IsError {
+			t.Errorf("MergeCategories(%v) succeeded", arguments)
+		}
+	}
+
+	result = call(map[string]any{"source_ids": []any{float64(2)}, "target": "9"})
+	structured, _ := result.StructuredContent.(map[string]any)
+	if argErr, ok := structured["error"].(*argumentError); !result.IsError || !ok || argErr.Argument != "target" || argErr.Reason != reasonUnknownReference {
+		t.Errorf("merge into the unknown target 9 = %#v, want an unknown target error", result)
+	}
+
+	deleted, categoryRequests = nil, 0
+	result = call(map[string]any{"sources": []any{"programming"}, "target": "tech"})
+	merge = result.StructuredContent.(categoryMerge)
+	if result.IsError || merge.TargetID != 1 || !slices.Equal(deleted, []string{"/v1/categories/2"}) {
+		t.Errorf("merge by title = %+v, deleted %v, want category 2 merged into 1", merge, deleted)
+	}
+	if categoryRequests != 1 || merge.Sources[0].Title != "Programming" {
+		t.Errorf("categories fetched %d times, sources %+v, want one listing used for titles too", categoryRequests, merge.Sources)
+	}
+}
@@ -0,0 +1,256 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"miniflux.app/v2/client"
+)
+
+const (
+	// completionCacheTTL bounds how long completions may miss new or renamed
+	// feeds, categories and users.
+	completionCacheTTL = time.Minute
+	maxCompletions     = 100
+	entryCompletions   = 20
+)
+
+// completionTarget names an argument of a prompt or resource template.
+type completionTarget struct {
+	ref      string
+	argument string
+}
+
+// completionCandidate is a completion value and the labels it is matched by.
+type completionCandidate struct {
+	value  string
+	labels []string
+}
+
+// completer returns the values completing the typed value, best first.
+type completer func(typed string) ([]string, error)
+
+// completionProvider completes prompt and resource template arguments from
+// cached lists of feeds, categories and users, and from entry searches.
+type completionProvider struct {
+	client     *client.Client
+	feeds      cachedLookup[client.Feeds]
+	categories cachedLookup[client.Categories]
+	users      cachedLookup[client.Users]
+	completers map[completionTarget]completer
+}
+
+func newCompletionProvider(minifluxServer *MinifluxServer) *completionProvider {
+	p := &completionProvider{client: minifluxServer.client}
+	p.feeds.fetch = func() (client.Feeds, error) { return p.client.Feeds() }
+	p.categories.fetch = func() (client.Categories, error) { return p.client.Categories() }
+	p.users.fetch = func() (client.Users, error) { return p.client.Users() }
+	p.completers = map[completionTarget]completer{
+		{"daily_digest", "category"}:    p.completeCategories,
+		{"summarize_entry", "entry_id"}: p.completeEntries,
+		{resourceFeedTemplate, "id"}:    p.completeFeeds,
+		{resourceEntryTemplate, "id"}:   p.completeEntries,
+	}
+	// Users are only completed while the user resource is exposed.
+	if minifluxServer.groupAvailable(toolGroupUsers) {
+		p.completers[completionTarget{resourceUserTemplate, "username"}] = p.completeUsers
+	}
+	return p
+}
+
+func (p *completionProvider) CompletePromptArgument(ctx context.Context, promptName string, argument mcp.CompleteArgument, _ mcp.CompleteContext) (*mcp.Completion, error) {
+	return p.complete(completionTarget{promptName, argument.Name}, argument.Value)
+}
+
+func (p *completionProvider) CompleteResourceArgument(ctx context.Context, uri string, argument mcp.CompleteArgument, _ mcp.CompleteContext) (*mcp.Completion, error) {
+	return p.complete(completionTarget{uri, argument.Name}, argument.Value)
+}
+
+func (p *completionProvider) complete(target completionTarget, value string) (*mcp.Completion, error) {
+	completion := &mcp.Completion{Values: []string{}}
+	complete, ok := p.completers[target]
+	if !ok {
+		return completion, nil
+	}
+	values, err := complete(value)
+	if err != nil {
+		return nil, err
+	}
+
+	completion.Values = values
+	completion.Total = len(completion.Values)
+	if len(completion.Values) > maxCompletions {
+		completion.Values = completion.Values[:maxCompletions]
+		completion.HasMore = true
+	}
+	return completion, nil
+}
+
+func (p *completionProvider) completeFeeds(typed string) ([]string, error) {
+	feeds, err := p.feeds.get()
+	if err != nil {
+		return nil, err
+	}
+	candidates := make([]completionCandidate, len(feeds))
+	for i, feed := range feeds {
+		id := strconv.FormatInt(feed.ID, 10)
+		candidates[i] = completionCandidate{value: id, labels: []string{feed.Title, id, feed.SiteURL, feed.FeedURL}}
+	}
+	return rankCompletions(candidates, typed), nil
+}
+
+func (p *completionProvider) completeCategories(typed string) ([]string, error) {
+	categories, err := p.categories.get()
+	if err != nil {
+		return nil, err
+	}
+	candidates := make([]completionCandidate, len(categories))
+	for i, category := range categories {
+		candidates[i] = completionCandidate{value: category.Title, labels: []string{category.Title, strconv.FormatInt(category.ID, 10)}}
+	}
+	return rankCompletions(candidates, typed), nil
+}
+
+func (p *completionProvider) completeUsers(typed string) ([]string, error) {
+	users, err := p.users.get()
+	if err != nil {
+		return nil, err
+	}
+	candidates := make([]completionCandidate, len(users))
+	for i, user := range users {
+		candidates[i] = completionCandidate{value: user.Username, labels: []string{user.Username}}
+	}
+	return rankCompletions(candidates, typed), nil
+}
+
+// completeEntries searches entries instead of caching them: there are too
+// many to list, so Miniflux's full-text search matches the typed value. While
+// an ID is typed, the entry with that ID comes first, followed by the newest
+// entries whose IDs start with it; with nothing typed, the newest entries.
+func (p *completionProvider) completeEntries(typed string) ([]string, error) {
+	filter := &client.Filter{Order: "published_at", Direction: "desc", Limit: entryCompletions}
+	id, err := strconv.ParseInt(typed, 10, 64)
+	if err != nil {
+		filter.Search = strings.TrimSpace(typed)
+	}
+
+	values := []string{}
+	if id > 0 {
+		entry, err := p.client.Entry(id)
+		switch {
+		case err == nil:
+			values = append(values, strconv.FormatInt(entry.ID, 10))
+		case !errors.Is(err, client.ErrNotFound):
+			return nil, err
+		}
+	}
+	entries, err := p.client.Entries(filter)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries.Entries {
+		value := strconv.FormatInt(entry.ID, 10)
+		if id > 0 && (!strings.HasPrefix(value, typed) || slices.Contains(values, value)) {
+			continue
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// rankCompletions returns the values of the candidates matching typed, best
+// matches first.
+func rankCompletions(candidates []completionCandidate, typed string) []string {
+	type ranked struct {
+		value string
+		label string
+		score int
+	}
+	var matches []ranked
+	seen := map[string]bool{}
+	for _, candidate := range candidates {
+		best := ranked{value: candidate.value, score: -1}
+		for _, label := range candidate.labels {
+			if label == "" {
+				continue
+			}
+			if score := matchScore(label, typed); score >= 0 && (best.score < 0 || score < best.score) {
+				best.label, best.score = label, score
+			}
+		}
+		if best.score >= 0 && !seen[candidate.value] {
+			seen[candidate.value] = true
+			matches = append(matches, best)
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score < matches[j].score
+		}
+		return strings.ToLower(matches[i].label) < strings.ToLower(matches[j].label)
+	})
+
+	values := make([]string, len(matches))
+	for i, match := range matches {
+		values[i] = match.value
+	}
+	return values
+}
+
+// matchScore ranks how well label matches typed, ignoring case: an exact
+// match scores 0, then a prefix, the prefix of a word, a substring and
+// finally the typed characters in order. It returns -1 when nothing matches.
+func matchScore(label, typed string) int {
+	label, typed = strings.ToLower(label), strings.ToLower(strings.TrimSpace(typed))
+	switch {
+	case label == typed:
+		return 0
+	case strings.HasPrefix(label, typed):
+		return 1
+	case strings.Contains(label, " "+typed):
+		return 2
+	case strings.Contains(label, typed):
+		return 3
+	}
+
+	remaining := typed
+	for _, r := range label {
+		if next, size := utf8.DecodeRuneInString(remaining); next == r {
+			remaining = remaining[size:]
+		}
+		if remaining == "" {
+			return 4
+		}
+	}
+	return -1
+}
+
+// cachedLookup caches the result of fetch for completionCacheTTL.
+type cachedLookup[T any] struct {
+	mu      sync.Mutex
+	fetch   func() (T, error)
+	value   T
+	fetched time.Time
+}
+
+func (c *cachedLookup[T]) get() (T, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.fetched.IsZero() && time.Since(c.fetched) < completionCacheTTL {
+		return c.value, nil
+	}
+	value, err := c.fetch()
+	if err != nil {
+		return value, err
+	}
+	c.value, c.fetched = value, time.Now()
+	return value, nil
+}
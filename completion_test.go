@@ -0,0 +1,118 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync/atomic"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"miniflux.app/v2/client"
+)
+
+func completeArgument(t *testing.T, mcpServer *server.MCPServer, ref map[string]any, name, value string) []string {
+	t.Helper()
+
+	params := map[string]any{"ref": ref, "argument": map[string]any{"name": name, "value": value}}
+	response, ok := sendRequest(t, mcpServer, "completion/complete", params).(mcp.JSONRPCResponse)
+	if !ok {
+		t.Fatalf("completion/complete of %s failed", name)
+	}
+	return response.Result.(mcp.CompleteResult).Completion.Values
+}
+
+func TestCompletions(t *testing.T) {
+	var feedRequests atomic.Int32
+	var search string
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response any
+		switch r.URL.Path {
+		case "/v1/feeds":
+			feedRequests.Add(1)
+			response = client.Feeds{
+				{ID: 1, Title: "Lobsters", SiteURL: "https://lobste.rs"},
+				{ID: 12, Title: "Hacker News", SiteURL: "https://news.ycombinator.com"},
+				{ID: 30, Title: "The Hacker Blog"},
+			}
+		case "/v1/categories":
+			response = client.Categories{{ID: 7, Title: "News"}, {ID: 8, Title: "Science"}}
+		case "/v1/users":
+			response = client.Users{{ID: 1, Username: "admin"}, {ID: 2, Username: "alice"}}
+		case "/v1/entries":
+			search = r.URL.Query().Get("search")
+			response = client.EntryResultSet{Total: 3, Entries: client.Entries{{ID: 91}, {ID: 90}, {ID: 12}}}
+		case "/v1/entries/9":
+			response = client.Entry{ID: 9}
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	defer apiServer.Close()
+
+	mcpServer := newMCPServer(&MinifluxServer{client: client.NewClient(apiServer.URL, "test-api-key")})
+	feedRef := map[string]any{"type": "ref/resource", "uri": resourceFeedTemplate}
+
+	if values := completeArgument(t, mcpServer, feedRef, "id", "hacker"); !slices.Equal(values, []string{"12", "30"}) {
+		t.Errorf("feed completions of hacker = %v, want [12 30]", values)
+	}
+	if values := completeArgument(t, mcpServer, feedRef, "id", "hkrnws"); !slices.Equal(values, []string{"12"}) {
+		t.Errorf("fuzzy feed completions = %v, want [12]", values)
+	}
+	if values := completeArgument(t, mcpServer, feedRef, "id", ""); len(values) != 3 {
+		t.Errorf("feed completions without input = %v, want all feeds", values)
+	}
+	if requests := feedRequests.Load(); requests != 1 {
+		t.Errorf("feeds were fetched %d times, want 1 cached lookup", requests)
+	}
+
+	categoryRef := map[string]any{"type": "ref/prompt", "name": "daily_digest"}
+	if values := completeArgument(t, mcpServer, categoryRef, "category", "sci"); !slices.Equal(values, []string{"Science"}) {
+		t.Errorf("category completions = %v, want [Science]", values)
+	}
+	userRef := map[string]any{"type": "ref/resource", "uri": resourceUserTemplate}
+	if values := completeArgument(t, mcpServer, userRef, "username", "al"); !slices.Equal(values, []string{"alice"}) {
+		t.Errorf("user completions = %v, want [alice]", values)
+	}
+	withoutUsers := newMCPServer(&MinifluxServer{client: client.NewClient(apiServer.URL, "test-api-key"), tools: toolConfig{Enabled: []string{toolGroupFeeds}}})
+	if values := completeArgument(t, withoutUsers, userRef, "username", "al"); len(values) != 0 {
+		t.Errorf("user completions with the users tools disabled = %v, want none", values)
+	}
+	entryRef := map[string]any{"type": "ref/prompt", "name": "summarize_entry"}
+	if values := completeArgument(t, mcpServer, entryRef, "entry_id", "golang"); !slices.Equal(values, []string{"91", "90", "12"}) || search != "golang" {
+		t.Errorf("entry completions = %v with search %q", values, search)
+	}
+	if values := completeArgument(t, mcpServer, entryRef, "entry_id", "9"); !slices.Equal(values, []string{"9", "91", "90"}) {
+		t.Errorf("entry completions of 9 = %v, want entry 9, then the newest IDs starting with 9", values)
+	}
+	if values := completeArgument(t, mcpServer, entryRef, "entry_id", "91"); !slices.Equal(values, []string{"91"}) {
+		t.Errorf("entry completions of 91 = %v, want only 91", values)
+	}
+	if values := completeArgument(t, mcpServer, entryRef, "other", "x"); len(values) != 0 {
+		t.Errorf("completions of an unknown argument = %v", values)
+	}
+}
+
+func TestMatchScore(t *testing.T) {
+	testCases := []struct {
+		label, typed string
+		want         int
+	}{
+		{"Hacker News", "hacker news", 0},
+		{"Hacker News", "hack", 1},
+		{"Hacker News", "new", 2},
+		{"Hacker News", "ker", 3},
+		{"Hacker News", "hn", 4},
+		{"Hacker News", "xyz", -1},
+	}
+	for _, testCase := range testCases {
+		if got := matchScore(testCase.label, testCase.typed); got != testCase.want {
+			t.Errorf("matchScore(%q, %q) = %d, want %d", testCase.label, testCase.typed, got, testCase.want)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"miniflux.app/v2/client"
+)
+
+// cursorVersion is bumped whenever the cursor encoding changes, so stale
+// cursors are rejected instead of being misread.
+const cursorVersion = 1
+
+// entryCursor is the decoded form of the opaque cursor returned by entry list
+// tools. It holds the filter of the next page, bound to the tool and to the
+// feed or category it was issued for.
+//
+// Pages sorted by entry ID continue from the last entry ID seen, so entries
+// added or removed meanwhile do not shift pages. Other orders continue by
+// offset, bounded to the entries that existed when paging started.
+type entryCursor struct {
+	Version int            `json:"v"`
+	Tool    string         `json:"tool"`
+	Scope   int64          `json:"scope,omitempty"`
+	Filter  map[string]any `json:"filter"`
+}
+
+// cursorArguments are the arguments that may accompany a cursor; every other
+// argument is part of the filter the cursor already encodes.
+var cursorArguments = []string{"cursor", "limit", "format", "fields", "max_chars"}
+
+// cursorScopeArguments name the feed or category of the feed and category
+// entry tools, which their cursors are checked against. For get_entries they
+// are filters like any other.
+var cursorScopeArguments = []string{"feed_id", "feed", "category_id", "category"}
+
+// entryFilterFromCursor decodes the cursor argument of an entry list tool. It
+// rejects cursors issued for another tool or scope and filter arguments that
+// would conflict with the cursor. A limit given along with the cursor
+// overrides the page size.
+func entryFilterFromCursor(request mcp.CallToolRequest, cursor string, limit *int, tool string, scope int64) (*client.Filter, error) {
+	values, err := argumentValues(request)
+	if err != nil {
+		return nil, err
+	}
+	accepted := cursorArguments
+	if scope != 0 {
+		accepted = slices.Concat(cursorArguments, cursorScopeArguments)
+	}
+	var conflicting []string
+	for name := range values {
+		if !slices.Contains(accepted, name) {
+			conflicting = append(conflicting, name)
+		}
+	}
+	if len(conflicting) > 0 {
+		sort.Strings(conflicting)
+		return nil, &argumentError{
+			Argument: conflicting[0],
+			Reason:   reasonInvalidArguments,
+			Message:  fmt.Sprintf("%s cannot be combined with cursor, which already encodes the filter", conflicting[0]),
+			Accepted: accepted,
+		}
+	}
+
+	invalid := &argumentError{
+		Argument: "cursor",
+		Reason:   reasonInvalidCursor,
+		Message:  fmt.Sprintf("cursor is not a valid %s cursor; start again without a cursor", tool),
+	}
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, invalid
+	}
+	var state entryCursor
+	if err := json.Unmarshal(decoded, &state); err != nil || state.Version != cursorVersion || state.Tool != tool || state.Scope != scope {
+		return nil, invalid
+	}
+	filterJSON, err := json.Marshal(state.Filter)
+	if err != nil {
+		return nil, invalid
+	}
+	var filter client.Filter
+	if err := json.Unmarshal(filterJSON, &filter); err != nil {
+		return nil, invalid
+	}
+	if limit != nil {
+		filter.Limit = *limit
+	}
+	return &filter, nil
+}
+
+func encodeEntryCursor(tool string, scope int64, filter client.Filter) string {
+	// Only the fields that are set are encoded, which keeps cursors short.
+	fields := map[string]any{}
+	filterValue := reflect.ValueOf(filter)
+	for i := 0; i < filterValue.NumField(); i++ {
+		if field := filterValue.Field(i); !field.IsZero() {
+			fields[filterValue.Type().Field(i).Name] = field.Interface()
+		}
+	}
+
+	state, err := json.Marshal(entryCursor{Version: cursorVersion, Tool: tool, Scope: scope, Filter: fields})
+	if err != nil {
+		// A filter only holds strings, integers and booleans.
+		panic(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(state)
+}
+
+// entryPagination computes the cursors that continue an entry list call.
+type entryPagination struct {
+	tool     string
+	scope    int64
+	filter   client.Filter
+	entries  client.Entries
+	more     bool
+	snapshot int64
+}
+
+// paginateEntries prepares the pagination of result, fetched with filter.
+// Offset-based pages record the highest matching entry ID with one extra
+// request, so later pages ignore entries added meanwhile.
+func paginateEntries(tool string, scope int64, filter *client.Filter, result *client.EntryResultSet, list func(*client.Filter) (*client.EntryResultSet, error)) (entryPagination, error) {
+	pagination := entryPagination{tool: tool, scope: scope, entries: result.Entries}
+	if filter != nil {
+		pagination.filter = *filter
+	}
+	if pagination.filter.Limit == 0 {
+		// Miniflux applies its default page size; keep it for later pages.
+		pagination.filter.Limit = len(result.Entries)
+	}
+
+	// The total counts the entries skipped by an offset too, including on
+	// the first page of a keyset listing.
+	pagination.more = pagination.filter.Offset+len(result.Entries) < result.Total
+	if pagination.keyset() {
+		return pagination, nil
+	}
+
+	if pagination.more && pagination.filter.BeforeEntryID == 0 {
+		latest := pagination.filter
+		latest.Order, latest.Direction, latest.Limit, latest.Offset = "id", "desc", 1, 0
+		latestResult, err := list(&latest)
+		if err != nil {
+			return entryPagination{}, err
+		}
+		if len(latestResult.Entries) > 0 {
+			pagination.snapshot = latestResult.Entries[0].ID
+		}
+	}
+	return pagination, nil
+}
+
+func (p entryPagination) keyset() bool {
+	return p.filter.Order == "id"
+}
+
+// nextCursor returns the cursor of the page following kept, the entries
+// actually returned to the caller, or "" when there is none.
+func (p entryPagination) nextCursor(kept client.Entries) string {
+	if !p.more && len(kept) >= len(p.entries) {
+		return ""
+	}
+
+	next := p.filter
+	switch {
+	case len(kept) == 0:
+		// Nothing fit in the response; the same page is still next.
+	case p.keyset():
+		next.Offset = 0
+		if next.Direction == "desc" {
+			next.BeforeEntryID = kept[len(kept)-1].ID
+		} else {
+			next.AfterEntryID = kept[len(kept)-1].ID
+		}
+	default:
+		next.Offset += len(kept)
+		if p.snapshot > 0 {
+			next.BeforeEntryID = p.snapshot + 1
+		}
+	}
+	return encodeEntryCursor(p.tool, p.scope, next)
+}
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"miniflux.app/v2/client"
+)
+
+// fakeEntryStore serves entry listings with the sorting, keyset and offset
+// parameters used by cursors.
+type fakeEntryStore struct {
+	mu      sync.Mutex
+	entries []*client.Entry
+}
+
+func newFakeEntryStore(count int) *fakeEntryStore {
+	store := &fakeEntryStore{}
+	for range count {
+		store.add(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Add(time.Duration(len(store.entries)) * time.Hour))
+	}
+	return store
+}
+
+func (s *fakeEntryStore) add(published time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := int64(len(s.entries) + 1)
+	s.entries = append(s.entries, &client.Entry{ID: id, FeedID: 42, Title: "Entry " + strconv.FormatInt(id, 10), Status: "unread", Date: published})
+}
+
+func (s *fakeEntryStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	query := r.URL.Query()
+	queryInt := func(name string) int64 {
+		value, _ := strconv.ParseInt(query.Get(name), 10, 64)
+		return value
+	}
+
+	var matching client.Entries
+	for _, entry := range s.entries {
+		if before := queryInt("before_entry_id"); before > 0 && entry.ID >= before {
+			continue
+		}
+		if after := queryInt("after_entry_id"); after > 0 && entry.ID <= after {
+			continue
+		}
+		matching = append(matching, entry)
+	}
+	slices.SortStableFunc(matching, func(a, b *client.Entry) int {
+		if query.Get("order") == "id" {
+			return int(a.ID - b.ID)
+		}
+		return a.Date.Compare(b.Date)
+	})
+	if query.Get("direction") == "desc" {
+		slices.Reverse(matching)
+	}
+
+	total := len(matching)
+	offset, limit := int(queryInt("offset")), int(queryInt("limit"))
+	if !query.Has("limit") {
+		limit = 100
+	}
+	matching = matching[min(offset, len(matching)):]
+	if limit > 0 && limit < len(matching) {
+		matching = matching[:limit]
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(client.EntryResultSet{Total: total, Entries: matching})
+}
+
+func callEntryList(t *testing.T, handler func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error), arguments map[string]any) *entryPage {
+	t.Helper()
+	result, err := handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: arguments}})
+	if err != nil {
+		t.Fatalf("handler returned error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("handler returned tool error: %#v", result.Content)
+	}
+	return result.StructuredContent.(*entryPage)
+}
+
+func entryIDs(entries client.Entries) []int64 {
+	ids := make([]int64, len(entries))
+	for i, entry := range entries {
+		ids[i] = entry.ID
+	}
+	return ids
+}
+
+func TestEntryCursorKeysetPagination(t *testing.T) {
+	store := newFakeEntryStore(25)
+	apiServer := httptest.NewServer(store)
+	defer apiServer.Close()
+	minifluxServer := &MinifluxServer{client: client.NewClient(apiServer.URL, "test-api-key")}
+
+	page := callEntryList(t, minifluxServer.GetEntries, map[string]any{"order": "id", "direction": "desc", "limit": 10})
+	if got := entryIDs(page.Entries); got[0] != 25 || got[9] != 16 || page.NextCursor == "" {
+		t.Fatalf("first page = %v, cursor %q", got, page.NextCursor)
+	}
+
+	// New entries arriving between pages must not shift the listing.
+	store.add(time.Now())
+	store.add(time.Now())
+
+	var seen []int64
+	seen = append(seen, entryIDs(page.Entries)...)
+	for page.NextCursor != "" {
+		page = callEntryList(t, minifluxServer.GetEntries, map[string]any{"cursor": page.NextCursor})
+		seen = append(seen, entryIDs(page.Entries)...)
+	}
+	want := make([]int64, 0, 25)
+	for id := int64(25); id >= 1; id-- {
+		want = append(want, id)
+	}
+	if !slices.Equal(seen, want) {
+		t.Errorf("paged ids = %v, want %v", seen, want)
+	}
+}
+
+func TestEntryCursorOffsetPaginationIsStable(t *testing.T) {
+	store := newFakeEntryStore(25)
+	apiServer := httptest.NewServer(store)
+	defer apiServer.Close()
+	minifluxServer := &MinifluxServer{client: client.NewClient(apiServer.URL, "test-api-key")}
+
+	page := callEntryList(t, minifluxServer.GetFeedEntries, map[string]any{"feed_id": 42, "limit": 10})
+	seen := entryIDs(page.Entries)
+	if len(seen) != 10 || page.NextCursor == "" {
+		t.Fatalf("first page = %v, cursor %q", seen, page.NextCursor)
+	}
+
+	// An entry published before every other one would shift offsets.
+	store.add(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	for page.NextCursor != "" {
+		page = callEntryList(t, minifluxServer.GetFeedEntries, map[string]any{"feed_id": 42, "cursor": page.NextCursor, "limit": 5})
+		if len(page.Entries) > 5 {
+			t.Fatalf("page has %d entries, want the limit given with the cursor", len(page.Entries))
+		}
+		seen = append(seen, entryIDs(page.Entries)...)
+	}
+	want := make([]int64, 0, 25)
+	for id := int64(1); id <= 25; id++ {
+		want = append(want, id)
+	}
+	if !slices.Equal(seen, want) {
+		t.Errorf("paged ids = %v, want %v", seen, want)
+	}
+}
+
+func TestEntryCursorRejectsMisuse(t *testing.T) {
+	store := newFakeEntryStore(5)
+	apiServer := httptest.NewServer(store)
+	defer apiServer.Close()
+	minifluxServer := &MinifluxServer{client: client.NewClient(apiServer.URL, "test-api-key")}
+
+	page := callEntryList(t, minifluxServer.GetFeedEntries, map[string]any{"feed_id": 42, "limit": 2})
+	entriesPage := callEntryList(t, minifluxServer.GetEntries, map[string]any{"limit": 2})
+	testCases := []struct {
+		name      string
+		handler   func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error)
+		arguments map[string]any
+		argument  string
+		reason    string
+	}{
+		{"other tool", minifluxServer.GetEntries, map[string]any{"cursor": page.NextCursor}, "cursor", reasonInvalidCursor},
+		{"other feed", minifluxServer.GetFeedEntries, map[string]any{"feed_id": 7, "cursor": page.NextCursor}, "cursor", reasonInvalidCursor},
+		{"garbage", minifluxServer.GetFeedEntries, map[string]any{"feed_id": 42, "cursor": "not a cursor"}, "cursor", reasonInvalidCursor},
+		{"conflicting filter", minifluxServer.GetFeedEntries, map[string]any{"feed_id": 42, "cursor": page.NextCursor, "status": "read"}, "status", reasonInvalidArguments},
+		{"feed filter", minifluxServer.GetEntries, map[string]any{"cursor": entriesPage.NextCursor, "feed_id": 42}, "feed_id", reasonInvalidArguments},
+		{"category filter", minifluxServer.GetEntries, map[string]any{"cursor": entriesPage.NextCursor, "category": "News"}, "category", reasonInvalidArguments},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			result, err := testCase.handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: testCase.arguments}})
+			if err != nil {
+				t.Fatalf("handler returned error: %v", err)
+			}
+			structured, _ := result.StructuredContent.(map[string]any)
+			argErr, ok := structured["error"].(*argumentError)
+			if !result.IsError || !ok || argErr.Argument != testCase.argument || argErr.Reason != testCase.reason {
+				t.Errorf("result = %#v, want %s error on %s", result.StructuredContent, testCase.reason, testCase.argument)
+			}
+		})
+	}
+}
+
+func TestLastEntryPageHasNoCursor(t *testing.T) {
+	apiServer := httptest.NewServer(newFakeEntryStore(3))
+	defer apiServer.Close()
+	minifluxServer := &MinifluxServer{client: client.NewClient(apiServer.URL, "test-api-key")}
+
+	page := callEntryList(t, minifluxServer.GetCategoryEntries, map[string]any{"category_id": 7})
+	if len(page.Entries) != 3 || page.NextCursor != "" {
+		t.Errorf("page = %v, cursor %q, want every entry and no cursor", entryIDs(page.Entries), page.NextCursor)
+	}
+}
+
+func TestKeysetPageAfterOffsetHasNoCursor(t *testing.T) {
+	apiServer := httptest.NewServer(newFakeEntryStore(3))
+	defer apiServer.Close()
+	minifluxServer := &MinifluxServer{client: client.NewClient(apiServer.URL, "test-api-key")}
+
+	page := callEntryList(t, minifluxServer.GetEntries, map[string]any{"order": "id", "direction": "asc", "offset": 2, "limit": 2})
+	if !slices.Equal(entryIDs(page.Entries), []int64{3}) || page.NextCursor != "" {
+		t.Errorf("page = %v, cursor %q, want the last entry and no cursor", entryIDs(page.Entries), page.NextCursor)
+	}
+}
@@ -0,0 +1,429 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"miniflux.app/v2/client"
+)
+
+// Reasons two feeds are reported as likely duplicates.
+const (
+	// duplicateFeedURL: the feed URLs differ only by scheme, a leading www.,
+	// the case of the host or a trailing slash.
+	duplicateFeedURL = "same_feed_url"
+	// duplicateFeedProxy: one feed is served by a feed proxy such as
+	// FeedBurner and both have the same site URL, and the same title when
+	// the site has several feeds.
+	duplicateFeedProxy = "feed_proxy"
+)
+
+// duplicateEntryPageSize is the number of entries fetched per request while
+// matching the starred entries of a duplicate feed with the kept feed.
+const duplicateEntryPageSize = 100
+
+// feedProxyHosts are hosts republishing feeds of other sites.
+var feedProxyHosts = map[string]bool{
+	"feeds.feedburner.com":  true,
+	"feeds2.feedburner.com": true,
+	"feedproxy.google.com":  true,
+}
+
+// duplicateFeedReport is the result of find_duplicate_feeds.
+type duplicateFeedReport struct {
+	Groups []duplicateFeedGroup `json:"groups"`
+}
+
+// duplicateFeedGroup is a set of feeds that likely deliver the same entries.
+// KeepFeedID is the feed dedupe_feeds keeps by default: the one with the most
+// starred entries, then the original over a proxy, https over http, and the
+// oldest.
+type duplicateFeedGroup struct {
+	Reasons    []string        `json:"reasons"`
+	KeepFeedID int64           `json:"keep_feed_id"`
+	Feeds      []duplicateFeed `json:"feeds"`
+}
+
+type duplicateFeed struct {
+	FeedID         int64  `json:"feed_id"`
+	Title          string `json:"title"`
+	FeedURL        string `json:"feed_url"`
+	SiteURL        string `json:"site_url,omitempty"`
+	Category       string `json:"category,omitempty"`
+	StarredEntries int    `json:"starred_entries"`
+}
+
+// feedDedupe is the result of dedupe_feeds. Without confirmation it only
+// lists the planned removals.
+type feedDedupe struct {
+	Confirmed bool               `json:"confirmed"`
+	Groups    []dedupedFeedGroup `json:"groups"`
+}
+
+type dedupedFeedGroup struct {
+	KeepFeedID int64         `json:"keep_feed_id"`
+	Removals   []feedRemoval `json:"removals"`
+}
+
+// feedRemoval reports the removal of one duplicate feed. Its starred entries
+// are starred in the kept feed first; Restarred counts the entries starred
+// there by this call. A feed with a starred entry missing from the kept feed
+// is not deleted.
+type feedRemoval struct {
+	FeedID         int64  `json:"feed_id"`
+	Title          string `json:"title"`
+	StarredEntries int    `json:"starred_entries"`
+	Restarred      int    `json:"restarred"`
+	Deleted        bool   `json:"deleted"`
+	Error          string `json:"error,omitempty"`
+}
+
+func (s *MinifluxServer) FindDuplicateFeeds(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args noArgs
+	if err := bindArguments(request, &args); err != nil {
+		return argumentErrorResult(err), nil
+	}
+
+	report, err := s.duplicateFeeds()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to find duplicate feeds: %v", err)), nil
+	}
+
+	reportJSON, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal duplicate feeds: %v", err)), nil
+	}
+	return mcp.NewToolResultStructured(report, string(reportJSON)), nil
+}
+
+func (s *MinifluxServer) DedupeFeeds(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args dedupeFeedsArgs
+	if err := bindArguments(request, &args); err != nil {
+		return argumentErrorResult(err), nil
+	}
+
+	report, err := s.duplicateFeeds()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to find duplicate feeds: %v", err)), nil
+	}
+	keepArgument, keepFeedIDs := "keep_feed_ids", args.KeepFeedIDs
+	switch {
+	case args.KeepFeedIDs != nil && args.KeepFeeds != nil:
+		return argumentErrorResult(&argumentError{Argument: "keep_feeds", Reason: reasonInvalidArguments, Message: "use either keep_feed_ids or keep_feeds, not both"}), nil
+	case args.KeepFeeds != nil:
+		keepArgument = "keep_feeds"
+		if keepFeedIDs, err = resolveReferences("feed", "keep_feed_ids", keepArgument, args.KeepFeeds, s.subscribedFeedMatches); err != nil {
+			return argumentErrorResult(err), nil
+		}
+	}
+	if err := keepFeeds(report.Groups, keepArgument, uniqueIDs(keepFeedIDs)); err != nil {
+		return argumentErrorResult(err), nil
+	}
+
+	dedupe := feedDedupe{Confirmed: args.Confirm, Groups: []dedupedFeedGroup{}}
+	failed := false
+	for _, group := range report.Groups {
+		deduped := dedupedFeedGroup{KeepFeedID: group.KeepFeedID, Removals: []feedRemoval{}}
+		kept := &keptEntryIndex{feedID: group.KeepFeedID, entries: map[string]*client.Entry{}}
+		for _, feed := range group.Feeds {
+			if feed.FeedID == group.KeepFeedID {
+				continue
+			}
+			removal := feedRemoval{FeedID: feed.FeedID, Title: feed.Title, StarredEntries: feed.StarredEntries}
+			if args.Confirm {
+				if err := ctx.Err(); err != nil {
+					removal.Error = err.Error()
+				} else {
+					s.removeDuplicateFeed(kept, &removal)
+				}
+				failed = failed || !removal.Deleted
+			}
+			deduped.Removals = append(deduped.Removals, removal)
+		}
+		dedupe.Groups = append(dedupe.Groups, deduped)
+	}
+
+	dedupeJSON, err := json.MarshalIndent(dedupe, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal feed dedupe: %v", err)), nil
+	}
+	result := mcp.NewToolResultStructured(dedupe, string(dedupeJSON))
+	result.IsError = failed
+	return result, nil
+}
+
+// duplicateFeeds groups the feeds of the user that likely duplicate each
+// other and counts the starred entries of the grouped feeds.
+func (s *MinifluxServer) duplicateFeeds() (*duplicateFeedReport, error) {
+	feeds, err := s.client.Feeds()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch feeds: %w", err)
+	}
+
+	report := &duplicateFeedReport{Groups: groupDuplicateFeeds(feeds)}
+	for i := range report.Groups {
+		group := &report.Groups[i]
+		for j := range group.Feeds {
+			feed := &group.Feeds[j]
+			starred, err := s.client.Entries(&client.Filter{FeedID: feed.FeedID, Starred: client.FilterOnlyStarred, Limit: 1})
+			if err != nil {
+				return nil, fmt.Errorf("failed to count starred entries of feed %d: %w", feed.FeedID, err)
+			}
+			feed.StarredEntries = starred.Total
+		}
+		group.KeepFeedID = slices.MinFunc(group.Feeds, compareFeedsToKeep).FeedID
+	}
+	return report, nil
+}
+
+// groupDuplicateFeeds groups feeds whose normalized feed URLs are equal, and
+// feeds served by a feed proxy with the original feed of the same site. When
+// the site has several feeds, the original is the one with the same title.
+// Groups and their feeds are ordered by feed ID.
+func groupDuplicateFeeds(feeds client.Feeds) []duplicateFeedGroup {
+	feeds = slices.Clone(feeds)
+	slices.SortFunc(feeds, func(a, b *client.Feed) int { return cmp.Compare(a.ID, b.ID) })
+
+	parents := make([]int, len(feeds))
+	for i := range parents {
+		parents[i] = i
+	}
+	var root func(i int) int
+	root = func(i int) int {
+		if parents[i] != i {
+			parents[i] = root(parents[i])
+		}
+		return parents[i]
+	}
+	type link struct {
+		a, b   int
+		reason string
+	}
+	var links []link
+	join := func(a, b int, reason string) {
+		parents[root(b)] = root(a)
+		links = append(links, link{a, b, reason})
+	}
+
+	byFeedURL := map[string]int{}
+	bySiteURL := map[string][]int{}
+	for i, feed := range feeds {
+		key := feedURLKey(feed.FeedURL)
+		if first, ok := byFeedURL[key]; ok {
+			join(first, i, duplicateFeedURL)
+		} else {
+			byFeedURL[key] = i
+		}
+		if feed.SiteURL != "" {
+			siteKey := feedURLKey(feed.SiteURL)
+			bySiteURL[siteKey] = append(bySiteURL[siteKey], i)
+		}
+	}
+	for i, feed := range feeds {
+		if !isFeedProxy(feed.FeedURL) || feed.SiteURL == "" {
+			continue
+		}
+		var originals []int
+		feedURLs := map[string]bool{}
+		for _, j := range bySiteURL[feedURLKey(feed.SiteURL)] {
+			if !isFeedProxy(feeds[j].FeedURL) {
+				originals = append(originals, j)
+				feedURLs[feedURLKey(feeds[j].FeedURL)] = true
+			}
+		}
+		for _, j := range originals {
+			if len(feedURLs) == 1 || strings.EqualFold(strings.TrimSpace(feeds[j].Title), strings.TrimSpace(feed.Title)) {
+				join(j, i, duplicateFeedProxy)
+			}
+		}
+	}
+
+	members := map[int][]int{}
+	for i := range feeds {
+		members[root(i)] = append(members[root(i)], i)
+	}
+	var groups []duplicateFeedGroup
+	for i := range feeds {
+		indexes := members[i]
+		if len(indexes) < 2 {
+			continue
+		}
+		group := duplicateFeedGroup{Reasons: []string{}}
+		for _, l := range links {
+			if root(l.a) == i && !slices.Contains(group.Reasons, l.reason) {
+				group.Reasons = append(group.Reasons, l.reason)
+			}
+		}
+		for _, index := range indexes {
+			feed := feeds[index]
+			duplicate := duplicateFeed{FeedID: feed.ID, Title: feed.Title, FeedURL: feed.FeedURL, SiteURL: feed.SiteURL}
+			if feed.Category != nil {
+				duplicate.Category = feed.Category.Title
+			}
+			group.Feeds = append(group.Feeds, duplicate)
+		}
+		groups = append(groups, group)
+	}
+	slices.SortFunc(groups, func(a, b duplicateFeedGroup) int { return cmp.Compare(a.Feeds[0].FeedID, b.Feeds[0].FeedID) })
+	if groups == nil {
+		groups = []duplicateFeedGroup{}
+	}
+	return groups
+}
+
+// compareFeedsToKeep orders the feeds of a group, the one to keep first.
+func compareFeedsToKeep(a, b duplicateFeed) int {
+	switch {
+	case a.StarredEntries != b.StarredEntries:
+		return b.StarredEntries - a.StarredEntries
+	case isFeedProxy(a.FeedURL) != isFeedProxy(b.FeedURL):
+		if isFeedProxy(a.FeedURL) {
+			return 1
+		}
+		return -1
+	case isHTTPS(a.FeedURL) != isHTTPS(b.FeedURL):
+		if isHTTPS(a.FeedURL) {
+			return -1
+		}
+		return 1
+	default:
+		return cmp.Compare(a.FeedID, b.FeedID)
+	}
+}
+
+// keepFeeds overrides the suggested feed to keep of the groups containing one
+// of feedIDs, given as argument.
+func keepFeeds(groups []duplicateFeedGroup, argument string, feedIDs []int64) error {
+	overridden := map[int]int64{}
+	for _, feedID := range feedIDs {
+		index := slices.IndexFunc(groups, func(group duplicateFeedGroup) bool {
+			return slices.ContainsFunc(group.Feeds, func(feed duplicateFeed) bool { return feed.FeedID == feedID })
+		})
+		if index < 0 {
+			return &argumentError{Argument: argument, Reason: reasonUnknownReference, Message: fmt.Sprintf("feed %d is not part of a duplicate group", feedID)}
+		}
+		if other, ok := overridden[index]; ok {
+			return &argumentError{Argument: argument, Reason: reasonInvalidArguments, Message: fmt.Sprintf("feeds %d and %d are duplicates of each other; keep only one of them", other, feedID)}
+		}
+		overridden[index] = feedID
+		groups[index].KeepFeedID = feedID
+	}
+	return nil
+}
+
+// removeDuplicateFeed stars the starred entries of a duplicate feed in the
+// kept feed, matched by hash or URL, and deletes the duplicate once all of
+// them are.
+func (s *MinifluxServer) removeDuplicateFeed(kept *keptEntryIndex, removal *feedRemoval) {
+	missing := 0
+	page := client.Filter{FeedID: removal.FeedID, Starred: client.FilterOnlyStarred, Order: "id", Direction: "asc", Limit: duplicateEntryPageSize}
+	for removal.StarredEntries > 0 {
+		starred, err := s.client.Entries(&page)
+		if err != nil {
+			removal.Error = fmt.Sprintf("failed to fetch starred entries: %v", err)
+			return
+		}
+		for _, entry := range starred.Entries {
+			counterpart, err := kept.find(s, entry)
+			if err != nil {
+				removal.Error = fmt.Sprintf("failed to fetch entries of feed %d: %v", kept.feedID, err)
+				return
+			}
+			switch {
+			case counterpart == nil:
+				missing++
+			case !counterpart.Starred:
+				if err := s.client.ToggleStarred(counterpart.ID); err != nil {
+					removal.Error = fmt.Sprintf("failed to star entry %d: %v", counterpart.ID, err)
+					return
+				}
+				counterpart.Starred = true
+				removal.Restarred++
+			}
+		}
+		if len(starred.Entries) < duplicateEntryPageSize {
+			break
+		}
+		page.AfterEntryID = starred.Entries[len(starred.Entries)-1].ID
+	}
+	if missing > 0 {
+		removal.Error = fmt.Sprintf("%d starred entries are not in feed %d, so the feed was kept", missing, kept.feedID)
+		return
+	}
+
+	if err := s.client.DeleteFeed(removal.FeedID); err != nil {
+		removal.Error = fmt.Sprintf("failed to delete feed: %v", err)
+		return
+	}
+	removal.Deleted = true
+}
+
+// keptEntryIndex indexes the entries of a kept feed by hash and URL. It pages
+// through the feed from its newest entry only as far as needed to find the
+// entries looked up, and keeps what it read for the next duplicate of the
+// group.
+type keptEntryIndex struct {
+	feedID   int64
+	entries  map[string]*client.Entry
+	beforeID int64
+	complete bool
+}
+
+// find returns the entry of the kept feed matching entry, or nil when the
+// kept feed has none.
+func (k *keptEntryIndex) find(s *MinifluxServer, entry *client.Entry) (*client.Entry, error) {
+	for {
+		if counterpart := k.entries["hash:"+entry.Hash]; counterpart != nil {
+			return counterpart, nil
+		}
+		if counterpart := k.entries["url:"+normalizeReferenceURL(entry.URL)]; counterpart != nil {
+			return counterpart, nil
+		}
+		if k.complete {
+			return nil, nil
+		}
+		page, err := s.client.Entries(&client.Filter{FeedID: k.feedID, BeforeEntryID: k.beforeID, Order: "id", Direction: "desc", Limit: duplicateEntryPageSize})
+		if err != nil {
+			return nil, err
+		}
+		for _, kept := range page.Entries {
+			k.entries["hash:"+kept.Hash] = kept
+			k.entries["url:"+normalizeReferenceURL(kept.URL)] = kept
+		}
+		if len(page.Entries) < duplicateEntryPageSize {
+			k.complete = true
+		} else {
+			k.beforeID = page.Entries[len(page.Entries)-1].ID
+		}
+	}
+}
+
+// feedURLKey normalizes a URL for duplicate detection, ignoring the scheme,
+// a leading www., the case of the host, the fragment and a trailing slash.
+func feedURLKey(rawURL string) string {
+	parsed, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || parsed.Host == "" {
+		return normalizeReferenceURL(rawURL)
+	}
+	key := strings.TrimPrefix(strings.ToLower(parsed.Host), "www.") + strings.TrimSuffix(parsed.EscapedPath(), "/")
+	if parsed.RawQuery != "" {
+		key += "?" + parsed.RawQuery
+	}
+	return key
+}
+
+func isFeedProxy(rawURL string) bool {
+	parsed, err := url.Parse(rawURL)
+	return err == nil && feedProxyHosts[strings.ToLower(parsed.Hostname())]
+}
+
+func isHTTPS(rawURL string) bool {
+	parsed, err := url.Parse(rawURL)
+	return err == nil && strings.EqualFold(parsed.Scheme, "https")
+}
@@ -0,0 +1,217 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"miniflux.app/v2/client"
+)
+
+// fakeDuplicateFeeds serves feeds and their entries, and records the feeds
+// deleted and the entries starred.
+type fakeDuplicateFeeds struct {
+	mu      sync.Mutex
+	feeds   client.Feeds
+	entries []*client.Entry
+	deleted []int64
+	starred []int64
+	// pages counts the requests for entries of each feed, starred or not.
+	pages map[int64]int
+}
+
+func (f *fakeDuplicateFeeds) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var response any
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/v1/feeds":
+		response = f.feeds
+	case r.Method == http.MethodGet && r.URL.Path == "/v1/entries":
+		query := r.URL.Query()
+		feedID, _ := strconv.ParseInt(query.Get("feed_id"), 10, 64)
+		afterEntryID, _ := strconv.ParseInt(query.Get("after_entry_id"), 10, 64)
+		beforeEntryID, _ := strconv.ParseInt(query.Get("before_entry_id"), 10, 64)
+		limit, _ := strconv.Atoi(query.Get("limit"))
+		f.pages[feedID]++
+		matched := client.Entries{}
+		for _, entry := range f.entries {
+			if entry.FeedID != feedID || (query.Get("starred") == "1" && !entry.Starred) || entry.ID <= afterEntryID || (beforeEntryID != 0 && entry.ID >= beforeEntryID) {
+				continue
+			}
+			copied := *entry
+			matched = append(matched, &copied)
+		}
+		slices.SortFunc(matched, func(a, b *client.Entry) int { return cmp.Compare(a.ID, b.ID) })
+		if query.Get("direction") == "desc" {
+			slices.Reverse(matched)
+		}
+		result := &client.EntryResultSet{Total: len(matched), Entries: matched}
+		if limit != 0 && len(matched) > limit {
+			result.Entries = matched[:limit]
+		}
+		response = result
+	case r.Method == http.MethodPut && strings.HasSuffix(r.URL.Path, "/star"):
+		entryID, _ := strconv.ParseInt(strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v1/entries/"), "/star"), 10, 64)
+		for _, entry := range f.entries {
+			if entry.ID == entryID {
+				entry.Starred = !entry.Starred
+			}
+		}
+		f.starred = append(f.starred, entryID)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/v1/feeds/"):
+		feedID, _ := strconv.ParseInt(strings.TrimPrefix(r.URL.Path, "/v1/feeds/"), 10, 64)
+		f.deleted = append(f.deleted, feedID)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+func newFakeDuplicateFeeds() *fakeDuplicateFeeds {
+	fake := &fakeDuplicateFeeds{
+		pages: map[int64]int{},
+		feeds: client.Feeds{
+			{ID: 1, Title: "Example", FeedURL: "https://example.com/feed/", SiteURL: "https://example.com"},
+			{ID: 2, Title: "Example", FeedURL: "http://www.Example.com/feed", SiteURL: "http://example.com/"},
+			{ID: 3, Title: "example", FeedURL: "https://feeds.feedburner.com/Example", SiteURL: "https://example.com"},
+			{ID: 4, Title: "Go Blog", FeedURL: "https://go.dev/blog/feed.atom", SiteURL: "https://go.dev/blog"},
+			{ID: 5, Title: "Example comments", FeedURL: "https://example.com/comments/feed/", SiteURL: "https://example.com"},
+			{ID: 6, Title: "Blog", FeedURL: "http://blog.test/atom.xml", SiteURL: "http://blog.test"},
+			{ID: 7, Title: "Blog", FeedURL: "https://blog.test/atom.xml/", SiteURL: "https://blog.test"},
+		},
+		entries: []*client.Entry{
+			{ID: 101, FeedID: 1, Hash: "a", URL: "https://example.com/post-a/", Starred: true},
+			{ID: 102, FeedID: 1, Hash: "b", URL: "https://example.com/post-b/"},
+			{ID: 201, FeedID: 2, Hash: "b2", URL: "https://example.com/post-b", Starred: true},
+			{ID: 301, FeedID: 3, Hash: "a", URL: "https://feedproxy.google.com/~r/example/post-a"},
+			{ID: 601, FeedID: 6, Hash: "z", URL: "http://blog.test/only-here", Starred: true},
+			{ID: 701, FeedID: 7, Hash: "y", URL: "https://blog.test/other"},
+		},
+	}
+	// Older entries of feed 1, so that the starred entries of its duplicates
+	// are found without reading all of it.
+	for id := int64(1); id <= 2*duplicateEntryPageSize; id++ {
+		fake.entries = append(fake.entries, &client.Entry{ID: id, FeedID: 1, Hash: "old-" + strconv.FormatInt(id, 10)})
+	}
+	return fake
+}
+
+func TestFindDuplicateFeeds(t *testing.T) {
+	apiServer := httptest.NewServer(newFakeDuplicateFeeds())
+	defer apiServer.Close()
+	minifluxServer := &MinifluxServer{client: client.NewClient(apiServer.URL, "test-api-key")}
+
+	result, err := minifluxServer.FindDuplicateFeeds(context.Background(), mcp.CallToolRequest{})
+	if err != nil || result.IsError {
+		t.Fatalf("FindDuplicateFeeds = %#v, %v", result, err)
+	}
+	report := result.StructuredContent.(*duplicateFeedReport)
+	if len(report.Groups) != 2 {
+		t.Fatalf("groups = %+v, want two", report.Groups)
+	}
+
+	example, blog := report.Groups[0], report.Groups[1]
+	var exampleIDs []int64
+	for _, feed := range example.Feeds {
+		exampleIDs = append(exampleIDs, feed.FeedID)
+	}
+	if !slices.Equal(exampleIDs, []int64{1, 2, 3}) || !slices.Equal(example.Reasons, []string{duplicateFeedURL, duplicateFeedProxy}) {
+		t.Errorf("first group = %+v, want feeds 1, 2 and 3 matched by URL and proxy", example)
+	}
+	if example.KeepFeedID != 1 || example.Feeds[1].StarredEntries != 1 {
+		t.Errorf("first group keeps %d with feeds %+v, want the https original feed 1", example.KeepFeedID, example.Feeds)
+	}
+	if len(blog.Feeds) != 2 || blog.KeepFeedID != 6 {
+		t.Errorf("second group = %+v, want feeds 6 and 7 keeping 6, which has a starred entry", blog)
+	}
+}
+
+func TestDedupeFeeds(t *testing.T) {
+	fake := newFakeDuplicateFeeds()
+	apiServer := httptest.NewServer(fake)
+	defer apiServer.Close()
+	minifluxServer := &MinifluxServer{client: client.NewClient(apiServer.URL, "test-api-key")}
+
+	call := func(arguments map[string]any) (*mcp.CallToolResult, feedDedupe) {
+		t.Helper()
+		result, err := minifluxServer.DedupeFeeds(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: arguments}})
+		if err != nil {
+			t.Fatalf("DedupeFeeds returned error: %v", err)
+		}
+		dedupe, _ := result.StructuredContent.(feedDedupe)
+		return result, dedupe
+	}
+
+	result, dedupe := call(nil)
+	if result.IsError || dedupe.Confirmed || len(fake.deleted) != 0 {
+		t.Fatalf("unconfirmed dedupe = %+v, deleted %v, want a plan only", dedupe, fake.deleted)
+	}
+	if len(dedupe.Groups) != 2 || len(dedupe.Groups[0].Removals) != 2 || dedupe.Groups[1].Removals[0].FeedID != 7 {
+		t.Errorf("planned groups = %+v, want feeds 2 and 3, then 7 removed", dedupe.Groups)
+	}
+
+	for _, testCase := range []struct {
+		keep   []any
+		reason string
+	}{
+		{[]any{float64(4)}, reasonUnknownReference},
+		{[]any{float64(2), float64(3)}, reasonInvalidArguments},
+	} {
+		result, _ := call(map[string]any{"keep_feed_ids": testCase.keep, "confirm": true})
+		structured, _ := result.StructuredContent.(map[string]any)
+		if argErr, ok := structured["error"].(*argumentError); !result.IsError || !ok || argErr.Reason != testCase.reason {
+			t.Errorf("keep_feed_ids %v = %#v, want a %s error", testCase.keep, result, testCase.reason)
+		}
+	}
+	for _, testCase := range []struct {
+		keep   []any
+		reason string
+	}{
+		{[]any{"Go Blog"}, reasonUnknownReference},
+		{[]any{"Example"}, reasonAmbiguousReference},
+		{[]any{"https://example.com/feed", "https://feeds.feedburner.com/Example"}, reasonInvalidArguments},
+	} {
+		result, _ := call(map[string]any{"keep_feeds": testCase.keep, "confirm": true})
+		structured, _ := result.StructuredContent.(map[string]any)
+		if argErr, ok := structured["error"].(*argumentError); !result.IsError || !ok || argErr.Argument != "keep_feeds" || argErr.Reason != testCase.reason {
+			t.Errorf("keep_feeds %v = %#v, want a %s error", testCase.keep, result, testCase.reason)
+		}
+	}
+	if len(fake.deleted) != 0 {
+		t.Fatalf("rejected calls deleted feeds %v", fake.deleted)
+	}
+
+	clear(fake.pages)
+	result, dedupe = call(map[string]any{"keep_feeds": []any{"https://blog.test/atom.xml"}, "confirm": true})
+	if !result.IsError {
+		t.Error("a kept duplicate is not reported as an error")
+	}
+	if !slices.Equal(fake.deleted, []int64{2, 3}) {
+		t.Errorf("deleted feeds = %v, want 2 and 3", fake.deleted)
+	}
+	if !slices.Equal(fake.starred, []int64{102}) || dedupe.Groups[0].Removals[0].Restarred != 1 {
+		t.Errorf("starred entries = %v, want entry 102 starred for the starred entry of feed 2", fake.starred)
+	}
+	if fake.pages[1] != 2 {
+		t.Errorf("fetched %d pages of entries of feed 1, want one to count its starred entries and one to find the starred entry of feed 2", fake.pages[1])
+	}
+	if removal := dedupe.Groups[1].Removals[0]; removal.FeedID != 6 || removal.Deleted || removal.Error == "" {
+		t.Errorf("removal of feed 6 = %+v, want it kept since its starred entry is not in feed 7", removal)
+	}
+}
@@ -0,0 +1,492 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"miniflux.app/v2/client"
+)
+
+// Output formats for entry tools. JSON returns the Miniflux representation as
+// is; Markdown and text render entry content from HTML and list entries
+// compactly. The structured content carries the full entries in every format,
+// and max_chars bounds it as well as the text.
+const (
+	formatJSON     = "json"
+	formatMarkdown = "markdown"
+	formatText     = "text"
+)
+
+const entryDateLayout = "2006-01-02 15:04"
+
+// entryView describes how entry results are rendered. MaxChars is the
+// response budget, zero meaning unlimited; Offset is the offset of the first
+// entry, used to tell the caller where to continue after truncation. Fields,
+// when set, projects listed entries in the JSON format. Pagination, when set,
+// issues the cursor of the next page.
+type entryView struct {
+	Format     string
+	MaxChars   int
+	Offset     int
+	Fields     fieldSelection
+	Pagination *entryPagination
+}
+
+// entryPage is the structured result of entry list tools. It extends the
+// Miniflux entry result set with a truncation notice.
+type entryPage struct {
+	Total      int            `json:"total"`
+	Entries    client.Entries `json:"entries"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+	Truncated  *truncation    `json:"truncated,omitempty"`
+}
+
+// entryDocument is the structured result of single entry tools.
+type entryDocument struct {
+	*client.Entry
+	Truncated *truncation `json:"truncated,omitempty"`
+}
+
+// truncation reports what was left out of a response to fit its budget.
+type truncation struct {
+	MaxChars         int    `json:"max_chars"`
+	OmittedEntries   int    `json:"omitted_entries,omitempty"`
+	ShortenedEntries int    `json:"shortened_entries,omitempty"`
+	NextOffset       *int   `json:"next_offset,omitempty"`
+	Hint             string `json:"hint"`
+}
+
+// entryView returns the rendering options of an entry tool call. A per-call
+// max_chars overrides the server's default budget, and list tools pass their
+// pagination, which also provides the offset of the page.
+func (s *MinifluxServer) entryView(format string, maxChars *int, fields fieldSelection, pagination *entryPagination) entryView {
+	view := entryView{Format: format, MaxChars: s.tools.MaxChars, Fields: fields, Pagination: pagination}
+	if maxChars != nil {
+		view.MaxChars = *maxChars
+	}
+	if pagination != nil {
+		view.Offset = pagination.filter.Offset
+	}
+	return view
+}
+
+// entryFieldSelection parses the fields argument of entry list tools, which
+// only applies to the JSON format.
+func entryFieldSelection(fields []string, format string) (fieldSelection, error) {
+	if len(fields) > 0 && format != formatJSON {
+		return nil, &argumentError{
+			Argument: "fields",
+			Reason:   reasonInvalidArguments,
+			Message:  fmt.Sprintf("fields can only be used with the %s format", formatJSON),
+		}
+	}
+	return parseFieldSelection(fields, reflect.TypeFor[client.Entry]())
+}
+
+func entriesResult(entries *client.EntryResultSet, view entryView) *mcp.CallToolResult {
+	page := &entryPage{Total: entries.Total, Entries: entries.Entries}
+	text, err := fitEntryPage(page, view)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal entries: %v", err))
+	}
+	structured, err := projectEntryPage(page, view.Fields)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to project entries: %v", err))
+	}
+	return mcp.NewToolResultStructured(structured, text)
+}
+
+// projectEntryPage returns page with its entries projected to the selected
+// fields, or page itself when no field is selected.
+func projectEntryPage(page *entryPage, selection fieldSelection) (any, error) {
+	if selection == nil {
+		return page, nil
+	}
+	entries, err := projectList(page.Entries, selection)
+	if err != nil {
+		return nil, err
+	}
+	projected := map[string]any{"total": page.Total, "entries": entries}
+	if page.NextCursor != "" {
+		projected["next_cursor"] = page.NextCursor
+	}
+	if page.Truncated != nil {
+		projected["truncated"] = page.Truncated
+	}
+	return projected, nil
+}
+
+func entryResult(entry *client.Entry, view entryView) *mcp.CallToolResult {
+	document := &entryDocument{Entry: entry}
+	text, err := fitEntryDocument(document, view)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal entry: %v", err))
+	}
+	return mcp.NewToolResultStructured(document, text)
+}
+
+func renderEntryPage(page *entryPage, view entryView) (string, error) {
+	var text string
+	switch view.Format {
+	case formatMarkdown:
+		text = renderEntryTable(&client.EntryResultSet{Total: page.Total, Entries: page.Entries})
+	case formatText:
+		text = renderEntryLines(&client.EntryResultSet{Total: page.Total, Entries: page.Entries})
+	default:
+		structured, err := projectEntryPage(page, view.Fields)
+		if err != nil {
+			return "", err
+		}
+		pageJSON, err := json.MarshalIndent(structured, "", "  ")
+		return string(pageJSON), err
+	}
+	if page.NextCursor != "" {
+		text += "\nNext cursor: " + page.NextCursor + "\n"
+	}
+	if page.Truncated != nil {
+		text += "\n" + page.Truncated.Hint + "\n"
+	}
+	return text, nil
+}
+
+func renderEntryDocument(document *entryDocument, format string) (string, error) {
+	var text string
+	switch format {
+	case formatMarkdown:
+		text = renderEntry(document.Entry, false)
+	case formatText:
+		text = renderEntry(document.Entry, true)
+	default:
+		documentJSON, err := json.MarshalIndent(document, "", "  ")
+		return string(documentJSON), err
+	}
+	if document.Truncated != nil {
+		text += "\n" + document.Truncated.Hint + "\n"
+	}
+	return text, nil
+}
+
+// fitEntryPage renders page within the view's budget, which both the text
+// and the structured page must fit. It first shortens the content of every
+// entry evenly and, when the entries do not fit even without content, drops
+// entries from the end of the page. The page is updated to match the returned
+// text.
+func fitEntryPage(page *entryPage, view entryView) (string, error) {
+	if view.Pagination != nil {
+		page.NextCursor = view.Pagination.nextCursor(page.Entries)
+	}
+	text, err := renderEntryPage(page, view)
+	if err != nil || view.MaxChars <= 0 {
+		return text, err
+	}
+	if fits, err := entryPageFits(page, view, text); err != nil || fits {
+		return text, err
+	}
+
+	entries := page.Entries
+	render := func(contentLimit, count int) (string, bool, error) {
+		page.Entries = truncateEntryContent(entries[:count], contentLimit)
+		if view.Pagination != nil {
+			page.NextCursor = view.Pagination.nextCursor(page.Entries)
+		}
+		page.Truncated = &truncation{
+			MaxChars:         view.MaxChars,
+			OmittedEntries:   len(entries) - count,
+			ShortenedEntries: countShortened(entries[:count], contentLimit),
+		}
+		if page.Truncated.OmittedEntries > 0 {
+			nextOffset := view.Offset + count
+			page.Truncated.NextOffset = &nextOffset
+		}
+		page.Truncated.Hint = page.Truncated.describe(page.NextCursor != "")
+		text, err := renderEntryPage(page, view)
+		if err != nil {
+			return "", false, err
+		}
+		fits, err := entryPageFits(page, view, text)
+		return text, fits, err
+	}
+
+	if _, fits, err := render(0, len(entries)); err != nil {
+		return "", err
+	} else if fits {
+		limit := largestFitting(maxContentLength(entries), func(limit int) bool {
+			_, fits, _ := render(limit, len(entries))
+			return fits
+		})
+		return first(render(limit, len(entries)))
+	}
+
+	count := largestFitting(len(entries)-1, func(count int) bool {
+		_, fits, _ := render(0, count)
+		return fits
+	})
+	return first(render(0, count))
+}
+
+// fitEntryDocument renders document within the view's budget, which both the
+// text and the structured document must fit, by shortening the entry content.
+func fitEntryDocument(document *entryDocument, view entryView) (string, error) {
+	text, err := renderEntryDocument(document, view.Format)
+	if err != nil || view.MaxChars <= 0 {
+		return text, err
+	}
+	if fits, err := withinBudget(text, document, view.MaxChars); err != nil || fits {
+		return text, err
+	}
+
+	entry := document.Entry
+	render := func(contentLimit int) (string, bool, error) {
+		document.Entry = truncateEntryContent(client.Entries{entry}, contentLimit)[0]
+		document.Truncated = &truncation{MaxChars: view.MaxChars, ShortenedEntries: 1}
+		document.Truncated.Hint = document.Truncated.describe(false)
+		text, err := renderEntryDocument(document, view.Format)
+		if err != nil {
+			return "", false, err
+		}
+		fits, err := withinBudget(text, document, view.MaxChars)
+		return text, fits, err
+	}
+
+	limit := largestFitting(maxContentLength(client.Entries{entry}), func(limit int) bool {
+		_, fits, _ := render(limit)
+		return fits
+	})
+	return first(render(limit))
+}
+
+// entryPageFits reports whether text and the structured content of page fit
+// the view's budget.
+func entryPageFits(page *entryPage, view entryView, text string) (bool, error) {
+	structured, err := projectEntryPage(page, view.Fields)
+	if err != nil {
+		return false, err
+	}
+	return withinBudget(text, structured, view.MaxChars)
+}
+
+// withinBudget reports whether text and the JSON encoding of structured are
+// both at most maxChars characters long.
+func withinBudget(text string, structured any, maxChars int) (bool, error) {
+	if charCount(text) > maxChars {
+		return false, nil
+	}
+	structuredJSON, err := json.Marshal(structured)
+	if err != nil {
+		return false, err
+	}
+	return charCount(string(structuredJSON)) <= maxChars, nil
+}
+
+func (t *truncation) describe(cursor bool) string {
+	var omitted []string
+	if t.OmittedEntries > 0 {
+		omitted = append(omitted, fmt.Sprintf("%d entries omitted", t.OmittedEntries))
+	}
+	if t.ShortenedEntries > 0 {
+		omitted = append(omitted, fmt.Sprintf("content shortened for %d entries", t.ShortenedEntries))
+	}
+	hint := fmt.Sprintf("Response truncated to %d characters: %s.", t.MaxChars, strings.Join(omitted, ", "))
+	switch {
+	case t.OmittedEntries > 0 && cursor:
+		hint += " Call again with cursor set to next_cursor to fetch the remaining entries."
+	case t.NextOffset != nil:
+		hint += fmt.Sprintf(" Call again with offset=%d to fetch the remaining entries.", *t.NextOffset)
+	}
+	if t.ShortenedEntries > 0 {
+		hint += " Fetch a single entry with a larger max_chars to read its full content."
+	}
+	return hint
+}
+
+// truncateEntryContent returns copies of entries whose content is cut to at
+// most limit characters.
+func truncateEntryContent(entries client.Entries, limit int) client.Entries {
+	truncated := make(client.Entries, len(entries))
+	for i, entry := range entries {
+		copied := *entry
+		copied.Content = truncateHTML(entry.Content, limit)
+		truncated[i] = &copied
+	}
+	return truncated
+}
+
+// truncateHTML cuts content to at most limit characters without leaving a
+// partial tag behind. Unclosed elements are tolerated by every renderer.
+func truncateHTML(content string, limit int) string {
+	if charCount(content) <= limit {
+		return content
+	}
+	if limit <= 0 {
+		return ""
+	}
+	cut := content[:runeOffset(content, limit)]
+	if open := strings.LastIndex(cut, "<"); open > strings.LastIndex(cut, ">") {
+		cut = cut[:open]
+	}
+	return cut + "…"
+}
+
+func countShortened(entries client.Entries, limit int) int {
+	count := 0
+	for _, entry := range entries {
+		if charCount(entry.Content) > limit {
+			count++
+		}
+	}
+	return count
+}
+
+func maxContentLength(entries client.Entries) int {
+	longest := 0
+	for _, entry := range entries {
+		longest = max(longest, charCount(entry.Content))
+	}
+	return longest
+}
+
+// largestFitting returns the largest n in [0, upper] for which fits reports
+// true, assuming fits is monotonic, or 0 when none does.
+func largestFitting(upper int, fits func(int) bool) int {
+	low, high := 0, upper
+	for low < high {
+		mid := low + (high-low+1)/2
+		if fits(mid) {
+			low = mid
+		} else {
+			high = mid - 1
+		}
+	}
+	return low
+}
+
+func first(text string, _ bool, err error) (string, error) {
+	return text, err
+}
+
+func charCount(text string) int {
+	return utf8.RuneCountInString(text)
+}
+
+func runeOffset(text string, runes int) int {
+	for offset := range text {
+		if runes == 0 {
+			return offset
+		}
+		runes--
+	}
+	return len(text)
+}
+
+// renderEntry renders an entry's metadata followed by its content converted
+// from HTML.
+func renderEntry(entry *client.Entry, plain bool) string {
+	var builder strings.Builder
+	if plain {
+		builder.WriteString(entry.Title + "\n\n")
+	} else {
+		builder.WriteString("# " + entry.Title + "\n\n")
+	}
+
+	metadata := [][2]string{
+		{"ID", fmt.Sprint(entry.ID)},
+		{"Feed", entryFeedTitle(entry)},
+		{"Author", entry.Author},
+		{"Published", formatEntryDate(entry)},
+		{"Status", entryStatus(entry)},
+		{"URL", entry.URL},
+	}
+	for _, field := range metadata {
+		if field[1] == "" {
+			continue
+		}
+		if plain {
+			fmt.Fprintf(&builder, "%s: %s\n", field[0], field[1])
+		} else {
+			fmt.Fprintf(&builder, "- **%s:** %s\n", field[0], field[1])
+		}
+	}
+
+	content := htmlToMarkdown(entry.Content)
+	if plain {
+		content = htmlToText(entry.Content)
+	}
+	if content != "" {
+		builder.WriteString("\n" + content + "\n")
+	}
+	return builder.String()
+}
+
+// renderEntryTable renders an entry list as a Markdown table.
+func renderEntryTable(entries *client.EntryResultSet) string {
+	var builder strings.Builder
+	builder.WriteString(entryCountSummary(entries) + "\n")
+	if len(entries.Entries) == 0 {
+		return builder.String()
+	}
+
+	builder.WriteString("\n| ID | Title | Feed | Date | Status |\n|---|---|---|---|---|\n")
+	for _, entry := range entries.Entries {
+		fmt.Fprintf(&builder, "| %d | %s | %s | %s | %s |\n",
+			entry.ID,
+			escapeTableCell(entry.Title),
+			escapeTableCell(entryFeedTitle(entry)),
+			formatEntryDate(entry),
+			entryStatus(entry),
+		)
+	}
+	return builder.String()
+}
+
+// renderEntryLines renders an entry list as one line per entry.
+func renderEntryLines(entries *client.EntryResultSet) string {
+	var builder strings.Builder
+	builder.WriteString(entryCountSummary(entries) + "\n")
+	for _, entry := range entries.Entries {
+		fmt.Fprintf(&builder, "%d\t%s\t%s\t%s\t%s\n",
+			entry.ID,
+			singleLine(entry.Title),
+			singleLine(entryFeedTitle(entry)),
+			formatEntryDate(entry),
+			entryStatus(entry),
+		)
+	}
+	return builder.String()
+}
+
+func entryCountSummary(entries *client.EntryResultSet) string {
+	return fmt.Sprintf("Showing %d of %d entries", len(entries.Entries), entries.Total)
+}
+
+func entryFeedTitle(entry *client.Entry) string {
+	if entry.Feed == nil {
+		return ""
+	}
+	return entry.Feed.Title
+}
+
+func formatEntryDate(entry *client.Entry) string {
+	if entry.Date.IsZero() {
+		return ""
+	}
+	return entry.Date.UTC().Format(entryDateLayout)
+}
+
+func entryStatus(entry *client.Entry) string {
+	if entry.Starred {
+		return entry.Status + ", starred"
+	}
+	return entry.Status
+}
+
+func singleLine(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+func escapeTableCell(text string) string {
+	return strings.ReplaceAll(singleLine(text), "|", `\|`)
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"miniflux.app/v2/client"
+)
+
+func sampleEntries(count, contentLength int) client.Entries {
+	entries := make(client.Entries, count)
+	for i := range entries {
+		entries[i] = &client.Entry{
+			ID:      int64(i + 1),
+			Title:   fmt.Sprintf("Entry %d", i+1),
+			Status:  "unread",
+			Content: "<p>" + strings.Repeat("é", contentLength) + "</p>",
+		}
+	}
+	return entries
+}
+
+func TestFitEntryPageKeepsResultsWithinBudget(t *testing.T) {
+	entries := sampleEntries(3, 100)
+	page := &entryPage{Total: 3, Entries: entries}
+	text, err := fitEntryPage(page, entryView{MaxChars: 100000})
+	if err != nil {
+		t.Fatalf("fitEntryPage returned error: %v", err)
+	}
+	if page.Truncated != nil || strings.Contains(text, "truncated") {
+		t.Errorf("page within budget was truncated: %+v", page.Truncated)
+	}
+}
+
+func TestFitEntryPageShortensContent(t *testing.T) {
+	entries := sampleEntries(3, 5000)
+	page := &entryPage{Total: 3, Entries: entries}
+	text, err := fitEntryPage(page, entryView{MaxChars: 4000})
+	if err != nil {
+		t.Fatalf("fitEntryPage returned error: %v", err)
+	}
+
+	if got := charCount(text); got > 4000 {
+		t.Errorf("text has %d characters, want at most 4000", got)
+	}
+	if len(page.Entries) != 3 || page.Truncated == nil {
+		t.Fatalf("page = %+v, want 3 shortened entries", page)
+	}
+	if page.Truncated.ShortenedEntries != 3 || page.Truncated.OmittedEntries != 0 || page.Truncated.NextOffset != nil {
+		t.Errorf("truncation = %+v", page.Truncated)
+	}
+	if content := page.Entries[0].Content; content == "" || !strings.HasSuffix(content, "…") {
+		t.Errorf("content = %q, want shortened content", content)
+	}
+	if charCount(entries[0].Content) != 5007 {
+		t.Error("fitEntryPage modified the original entries")
+	}
+
+	var decoded entryPage
+	if err := json.Unmarshal([]byte(text), &decoded); err != nil || decoded.Truncated == nil {
+		t.Errorf("JSON text does not carry the truncation notice: %v", err)
+	}
+}
+
+func TestFitEntryPageDropsEntries(t *testing.T) {
+	page := &entryPage{Total: 500, Entries: sampleEntries(100, 10)}
+	text, err := fitEntryPage(page, entryView{Format: formatMarkdown, MaxChars: 1500, Offset: 200})
+	if err != nil {
+		t.Fatalf("fitEntryPage returned error: %v", err)
+	}
+
+	if got := charCount(text); got > 1500 {
+		t.Errorf("text has %d characters, want at most 1500", got)
+	}
+	kept := len(page.Entries)
+	if kept == 0 || kept == 100 {
+		t.Fatalf("kept %d entries, want some but not all", kept)
+	}
+	truncated := page.Truncated
+	if truncated == nil || truncated.OmittedEntries != 100-kept || truncated.NextOffset == nil || *truncated.NextOffset != 200+kept {
+		t.Fatalf("truncation = %+v, want next offset %d", truncated, 200+kept)
+	}
+	if !strings.Contains(text, fmt.Sprintf("offset=%d", 200+kept)) {
+		t.Errorf("text does not contain the continuation hint:\n%s", text)
+	}
+}
+
+func TestFitEntryDocumentShortensContent(t *testing.T) {
+	entry := sampleEntries(1, 10000)[0]
+	document := &entryDocument{Entry: entry}
+	text, err := fitEntryDocument(document, entryView{Format: formatText, MaxChars: 2000})
+	if err != nil {
+		t.Fatalf("fitEntryDocument returned error: %v", err)
+	}
+	if got := charCount(text); got > 2000 || got < 1000 {
+		t.Errorf("text has %d characters, want close to 2000", got)
+	}
+	if document.Truncated == nil || document.Truncated.ShortenedEntries != 1 {
+		t.Errorf("truncation = %+v", document.Truncated)
+	}
+}
+
+func TestTruncateHTML(t *testing.T) {
+	for _, testCase := range []struct {
+		content string
+		limit   int
+		want    string
+	}{
+		{"<p>short</p>", 100, "<p>short</p>"},
+		{"<p>hello world</p>", 10, "<p>hello w…"},
+		{`<p>hi <a href="https://example.org">there</a></p>`, 12, "<p>hi …"},
+		{"<p>hello</p>", 0, ""},
+	} {
+		if got := truncateHTML(testCase.content, testCase.limit); got != testCase.want {
+			t.Errorf("truncateHTML(%q, %d) = %q, want %q", testCase.content, testCase.limit, got, testCase.want)
+		}
+	}
+}
+
+func TestFeedEntriesMaxChars(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		result := client.EntryResultSet{Total: 1000, Entries: sampleEntries(100, 2000)}
+		if r.URL.Query().Get("order") == "id" {
+			// Snapshot of the latest entry taken for the next cursor.
+			result.Entries = result.Entries[:1]
+		} else if got := r.URL.Query().Get("offset"); got != "40" {
+			t.Errorf("offset = %q, want 40", got)
+		}
+		if err := json.NewEncoder(w).Encode(result); err != nil {
+			t.Errorf("encode response body: %v", err)
+		}
+	}))
+	defer apiServer.Close()
+
+	minifluxServer := &MinifluxServer{
+		client: client.NewClient(apiServer.URL, "test-api-key"),
+		tools:  toolConfig{MaxChars: 1000000},
+	}
+	request := mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Arguments: map[string]interface{}{"feed_id": float64(42), "offset": float64(40), "max_chars": float64(5000)},
+		},
+	}
+
+	result, err := minifluxServer.GetFeedEntries(context.Background(), request)
+	if err != nil {
+		t.Fatalf("GetFeedEntries returned error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("GetFeedEntries returned tool error: %#v", result.Content)
+	}
+	text, ok := mcp.AsTextContent(result.Content[0])
+	if !ok || charCount(text.Text) > 5000 {
+		t.Errorf("text content exceeds max_chars: %d characters", charCount(text.Text))
+	}
+	page, ok := result.StructuredContent.(*entryPage)
+	if !ok || page.Truncated == nil || page.Truncated.NextOffset == nil || *page.Truncated.NextOffset != 40+len(page.Entries) {
+		t.Errorf("structured content = %+v, want a continuation offset", result.StructuredContent)
+	}
+}
+
+func TestMaxCharsBoundsStructuredContent(t *testing.T) {
+	for _, format := range []string{formatJSON, formatMarkdown, formatText} {
+		t.Run(format, func(t *testing.T) {
+			// A single entry cannot be shortened below its metadata, so the
+			// budget leaves room for it.
+			view := entryView{Format: format, MaxChars: 1000}
+			results := map[string]*mcp.CallToolResult{
+				"list":  entriesResult(&client.EntryResultSet{Total: 20, Entries: sampleEntries(20, 100000)}, view),
+				"entry": entryResult(sampleEntries(1, 100000)[0], view),
+			}
+			for kind, result := range results {
+				if result.IsError {
+					t.Fatalf("%s returned error: %#v", kind, result.Content)
+				}
+				structuredJSON, err := json.Marshal(result.StructuredContent)
+				if err != nil {
+					t.Fatalf("marshal %s structured content: %v", kind, err)
+				}
+				if got := charCount(string(structuredJSON)); got > 1000 {
+					t.Errorf("%s structured content has %d characters, want at most 1000", kind, got)
+				}
+			}
+		})
+	}
+}
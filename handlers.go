@@ -11,22 +11,15 @@ import (
 
 // Feed Management Methods (Additional)
 func (s *MinifluxServer) GetFeed(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	args := request.Params.Arguments
-	if args == nil {
-		return mcp.NewToolResultError("feed_id is required"), nil
+	var args feedArgs
+	if err := bindArguments(request, &args); err != nil {
+		return argumentErrorResult(err), nil
 	}
-
-	argsMap, ok := args.(map[string]interface{})
-	if !ok {
-		return mcp.NewToolResultError("Invalid arguments format"), nil
-	}
-
-	feedIDFloat, ok := argsMap["feed_id"].(float64)
-	if !ok {
-		return mcp.NewToolResultError("feed_id must be a number"), nil
+	feedID, err := s.resolveFeed(args.feedReference)
+	if err != nil {
+		return argumentErrorResult(err), nil
 	}
 
-	feedID := int64(feedIDFloat)
 	feed, err := s.client.Feed(feedID)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to fetch feed: %v", err)), nil
@@ -37,95 +30,54 @@ func (s *MinifluxServer) GetFeed(ctx context.Context, request mcp.CallToolReques
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal feed: %v", err)), nil
 	}
 
-	return mcp.NewToolResultText(string(feedJSON)), nil
+	return mcp.NewToolResultStructured(feed, string(feedJSON)), nil
 }
 
 func (s *MinifluxServer) UpdateFeed(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	args := request.Params.Arguments
-	if args == nil {
-		return mcp.NewToolResultError("feed_id and at least one field to update are required"), nil
-	}
-
-	argsMap, ok := args.(map[string]interface{})
-	if !ok {
-		return mcp.NewToolResultError("Invalid arguments format"), nil
-	}
-
-	feedIDFloat, ok := argsMap["feed_id"].(float64)
-	if !ok {
-		return mcp.NewToolResultError("feed_id must be a number"), nil
-	}
-
-	changes := &client.FeedModificationRequest{}
-	hasChanges := false
-
-	stringFields := map[string]**string{
-		"feed_url":                 &changes.FeedURL,
-		"site_url":                 &changes.SiteURL,
-		"title":                    &changes.Title,
-		"scraper_rules":            &changes.ScraperRules,
-		"rewrite_rules":            &changes.RewriteRules,
-		"urlrewrite_rules":         &changes.UrlRewriteRules,
-		"blocklist_rules":          &changes.BlocklistRules,
-		"keeplist_rules":           &changes.KeeplistRules,
-		"block_filter_entry_rules": &changes.BlockFilterEntryRules,
-		"keep_filter_entry_rules":  &changes.KeepFilterEntryRules,
-		"user_agent":               &changes.UserAgent,
-		"cookie":                   &changes.Cookie,
-		"username":                 &changes.Username,
-		"password":                 &changes.Password,
-		"proxy_url":                &changes.ProxyURL,
-	}
-	for name, target := range stringFields {
-		value, exists := argsMap[name]
-		if !exists {
-			continue
-		}
-		stringValue, ok := value.(string)
-		if !ok {
-			return mcp.NewToolResultError(fmt.Sprintf("%s must be a string", name)), nil
-		}
-		*target = &stringValue
-		hasChanges = true
-	}
-
-	boolFields := map[string]**bool{
-		"crawler":                        &changes.Crawler,
-		"disabled":                       &changes.Disabled,
-		"ignore_http_cache":              &changes.IgnoreHTTPCache,
-		"allow_self_signed_certificates": &changes.AllowSelfSignedCertificates,
-		"fetch_via_proxy":                &changes.FetchViaProxy,
-		"hide_globally":                  &changes.HideGlobally,
-		"disable_http2":                  &changes.DisableHTTP2,
-	}
-	for name, target := range boolFields {
-		value, exists := argsMap[name]
-		if !exists {
-			continue
-		}
-		boolValue, ok := value.(bool)
-		if !ok {
-			return mcp.NewToolResultError(fmt.Sprintf("%s must be a boolean", name)), nil
+	var args updateFeedArgs
+	if err := bindArguments(request, &args); err != nil {
+		return argumentErrorResult(err), nil
+	}
+	feedID, err := s.resolveFeed(args.feedReference)
+	if err != nil {
+		return argumentErrorResult(err), nil
+	}
+
+	changes := &client.FeedModificationRequest{
+		FeedURL:                     args.FeedURL,
+		SiteURL:                     args.SiteURL,
+		Title:                       args.Title,
+		ScraperRules:                args.ScraperRules,
+		RewriteRules:                args.RewriteRules,
+		UrlRewriteRules:             args.UrlRewriteRules,
+		BlocklistRules:              args.BlocklistRules,
+		KeeplistRules:               args.KeeplistRules,
+		BlockFilterEntryRules:       args.BlockFilterEntryRules,
+		KeepFilterEntryRules:        args.KeepFilterEntryRules,
+		Crawler:                     args.Crawler,
+		UserAgent:                   args.UserAgent,
+		Cookie:                      args.Cookie,
+		Username:                    args.Username,
+		Password:                    args.Password,
+		Disabled:                    args.Disabled,
+		IgnoreHTTPCache:             args.IgnoreHTTPCache,
+		AllowSelfSignedCertificates: args.AllowSelfSignedCertificates,
+		FetchViaProxy:               args.FetchViaProxy,
+		HideGlobally:                args.HideGlobally,
+		DisableHTTP2:                args.DisableHTTP2,
+		ProxyURL:                    args.ProxyURL,
+	}
+	if args.categoryReference.given() {
+		categoryID, err := s.resolveCategory(args.categoryReference)
+		if err != nil {
+			return argumentErrorResult(err), nil
 		}
-		*target = &boolValue
-		hasChanges = true
-	}
-
-	if value, exists := argsMap["category_id"]; exists {
-		categoryIDFloat, ok := value.(float64)
-		if !ok {
-			return mcp.NewToolResultError("category_id must be a number"), nil
-		}
-		categoryID := int64(categoryIDFloat)
 		changes.CategoryID = &categoryID
-		hasChanges = true
 	}
-
-	if !hasChanges {
+	if *changes == (client.FeedModificationRequest{}) {
 		return mcp.NewToolResultError("at least one field to update is required"), nil
 	}
 
-	feedID := int64(feedIDFloat)
 	updatedFeed, err := s.client.UpdateFeed(feedID, changes)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to update feed: %v", err)), nil
@@ -136,27 +88,20 @@ func (s *MinifluxServer) UpdateFeed(ctx context.Context, request mcp.CallToolReq
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal updated feed: %v", err)), nil
 	}
 
-	return mcp.NewToolResultText(string(feedJSON)), nil
+	return mcp.NewToolResultStructured(updatedFeed, string(feedJSON)), nil
 }
 
 func (s *MinifluxServer) DeleteFeed(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	args := request.Params.Arguments
-	if args == nil {
-		return mcp.NewToolResultError("feed_id is required"), nil
+	var args feedArgs
+	if err := bindArguments(request, &args); err != nil {
+		return argumentErrorResult(err), nil
 	}
-
-	argsMap, ok := args.(map[string]interface{})
-	if !ok {
-		return mcp.NewToolResultError("Invalid arguments format"), nil
-	}
-
-	feedIDFloat, ok := argsMap["feed_id"].(float64)
-	if !ok {
-		return mcp.NewToolResultError("feed_id must be a number"), nil
+	feedID, err := s.resolveFeed(args.feedReference)
+	if err != nil {
+		return argumentErrorResult(err), nil
 	}
 
-	feedID := int64(feedIDFloat)
-	err := s.client.DeleteFeed(feedID)
+	err = s.client.DeleteFeed(feedID)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to delete feed: %v", err)), nil
 	}
@@ -165,110 +110,96 @@ func (s *MinifluxServer) DeleteFeed(ctx context.Context, request mcp.CallToolReq
 }
 
 func (s *MinifluxServer) GetFeedEntries(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	args := request.Params.Arguments
-	if args == nil {
-		return mcp.NewToolResultError("feed_id is required"), nil
+	var args feedEntriesArgs
+	if err := bindArguments(request, &args); err != nil {
+		return argumentErrorResult(err), nil
 	}
-
-	argsMap, ok := args.(map[string]interface{})
-	if !ok {
-		return mcp.NewToolResultError("Invalid arguments format"), nil
+	feedID, err := s.resolveFeed(args.feedReference)
+	if err != nil {
+		return argumentErrorResult(err), nil
 	}
-
-	feedIDFloat, ok := argsMap["feed_id"].(float64)
-	if !ok {
-		return mcp.NewToolResultError("feed_id must be a number"), nil
+	fields, err := entryFieldSelection(args.Fields, args.Format)
+	if err != nil {
+		return argumentErrorResult(err), nil
 	}
 
-	feedID := int64(feedIDFloat)
-
-	// Parse optional filter parameters
-	var filter *client.Filter
-	if statusStr, ok := argsMap["status"].(string); ok {
-		filter = &client.Filter{Status: statusStr}
-	}
-	if limitFloat, ok := argsMap["limit"].(float64); ok {
-		if filter == nil {
-			filter = &client.Filter{}
+	filter := scopedEntriesFilter(args.Status, args.Limit, args.Offset, args.Order, args.Direction)
+	if args.Cursor != nil {
+		if filter, err = entryFilterFromCursor(request, *args.Cursor, args.Limit, "get_feed_entries", feedID); err != nil {
+			return argumentErrorResult(err), nil
 		}
-		limit := int(limitFloat)
-		filter.Limit = limit
-	}
-	if offsetFloat, ok := argsMap["offset"].(float64); ok {
-		if filter == nil {
-			filter = &client.Filter{}
-		}
-		offset := int(offsetFloat)
-		filter.Offset = offset
 	}
 
-	entries, err := s.client.FeedEntries(feedID, filter)
+	listEntries := func(filter *client.Filter) (*client.EntryResultSet, error) {
+		return s.client.FeedEntries(feedID, filter)
+	}
+	entries, err := listEntries(filter)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to fetch feed entries: %v", err)), nil
 	}
-
-	entriesJSON, err := json.MarshalIndent(entries, "", "  ")
+	pagination, err := paginateEntries("get_feed_entries", feedID, filter, entries, listEntries)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal entries: %v", err)), nil
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to fetch feed entries: %v", err)), nil
 	}
 
-	return mcp.NewToolResultText(string(entriesJSON)), nil
+	return entriesResult(entries, s.entryView(args.Format, args.MaxChars, fields, &pagination)), nil
 }
 
-func (s *MinifluxServer) GetFeedEntry(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	args := request.Params.Arguments
-	if args == nil {
-		return mcp.NewToolResultError("feed_id and entry_id are required"), nil
+// scopedEntriesFilter builds the filter of the feed and category entry tools.
+// It returns nil when no filter argument was given, so Miniflux applies its
+// defaults.
+func scopedEntriesFilter(status *string, limit, offset *int, order, direction *string) *client.Filter {
+	if status == nil && limit == nil && offset == nil && order == nil && direction == nil {
+		return nil
 	}
 
-	argsMap, ok := args.(map[string]interface{})
-	if !ok {
-		return mcp.NewToolResultError("Invalid arguments format"), nil
+	filter := &client.Filter{}
+	if status != nil {
+		filter.Status = *status
 	}
-
-	feedIDFloat, ok := argsMap["feed_id"].(float64)
-	if !ok {
-		return mcp.NewToolResultError("feed_id must be a number"), nil
+	if limit != nil {
+		filter.Limit = *limit
 	}
-
-	entryIDFloat, ok := argsMap["entry_id"].(float64)
-	if !ok {
-		return mcp.NewToolResultError("entry_id must be a number"), nil
+	if offset != nil {
+		filter.Offset = *offset
 	}
+	if order != nil {
+		filter.Order = *order
+	}
+	if direction != nil {
+		filter.Direction = *direction
+	}
+	return filter
+}
 
-	feedID := int64(feedIDFloat)
-	entryID := int64(entryIDFloat)
-
-	entry, err := s.client.FeedEntry(feedID, entryID)
+func (s *MinifluxServer) GetFeedEntry(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args feedEntryArgs
+	if err := bindArguments(request, &args); err != nil {
+		return argumentErrorResult(err), nil
+	}
+	feedID, err := s.resolveFeed(args.feedReference)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to fetch feed entry: %v", err)), nil
+		return argumentErrorResult(err), nil
 	}
 
-	entryJSON, err := json.MarshalIndent(entry, "", "  ")
+	entry, err := s.client.FeedEntry(feedID, args.EntryID)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal entry: %v", err)), nil
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to fetch feed entry: %v", err)), nil
 	}
 
-	return mcp.NewToolResultText(string(entryJSON)), nil
+	return entryResult(entry, s.entryView(args.Format, args.MaxChars, nil, nil)), nil
 }
 
 func (s *MinifluxServer) GetFeedIcon(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	args := request.Params.Arguments
-	if args == nil {
-		return mcp.NewToolResultError("feed_id is required"), nil
+	var args feedArgs
+	if err := bindArguments(request, &args); err != nil {
+		return argumentErrorResult(err), nil
 	}
-
-	argsMap, ok := args.(map[string]interface{})
-	if !ok {
-		return mcp.NewToolResultError("Invalid arguments format"), nil
-	}
-
-	feedIDFloat, ok := argsMap["feed_id"].(float64)
-	if !ok {
-		return mcp.NewToolResultError("feed_id must be a number"), nil
+	feedID, err := s.resolveFeed(args.feedReference)
+	if err != nil {
+		return argumentErrorResult(err), nil
 	}
 
-	feedID := int64(feedIDFloat)
 	icon, err := s.client.FeedIcon(feedID)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to fetch feed icon: %v", err)), nil
@@ -283,23 +214,16 @@ func (s *MinifluxServer) GetFeedIcon(ctx context.Context, request mcp.CallToolRe
 }
 
 func (s *MinifluxServer) MarkFeedAsRead(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	args := request.Params.Arguments
-	if args == nil {
-		return mcp.NewToolResultError("feed_id is required"), nil
-	}
-
-	argsMap, ok := args.(map[string]interface{})
-	if !ok {
-		return mcp.NewToolResultError("Invalid arguments format"), nil
+	var args feedArgs
+	if err := bindArguments(request, &args); err != nil {
+		return argumentErrorResult(err), nil
 	}
-
-	feedIDFloat, ok := argsMap["feed_id"].(float64)
-	if !ok {
-		return mcp.NewToolResultError("feed_id must be a number"), nil
+	feedID, err := s.resolveFeed(args.feedReference)
+	if err != nil {
+		return argumentErrorResult(err), nil
 	}
 
-	feedID := int64(feedIDFloat)
-	err := s.client.MarkFeedAsRead(feedID)
+	err = s.client.MarkFeedAsRead(feedID)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to mark feed as read: %v", err)), nil
 	}
@@ -308,6 +232,11 @@ func (s *MinifluxServer) MarkFeedAsRead(ctx context.Context, request mcp.CallToo
 }
 
 func (s *MinifluxServer) RefreshAllFeeds(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args noArgs
+	if err := bindArguments(request, &args); err != nil {
+		return argumentErrorResult(err), nil
+	}
+
 	err := s.client.RefreshAllFeeds()
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to refresh all feeds: %v", err)), nil
@@ -318,110 +247,80 @@ func (s *MinifluxServer) RefreshAllFeeds(ctx context.Context, request mcp.CallTo
 
 // Entry Management Methods (Additional)
 func (s *MinifluxServer) GetCategoryEntry(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	args := request.Params.Arguments
-	if args == nil {
-		return mcp.NewToolResultError("category_id and entry_id are required"), nil
+	var args categoryEntryArgs
+	if err := bindArguments(request, &args); err != nil {
+		return argumentErrorResult(err), nil
 	}
-
-	argsMap, ok := args.(map[string]interface{})
-	if !ok {
-		return mcp.NewToolResultError("Invalid arguments format"), nil
+	categoryID, err := s.resolveCategory(args.categoryReference)
+	if err != nil {
+		return argumentErrorResult(err), nil
 	}
 
-	categoryIDFloat, ok := argsMap["category_id"].(float64)
-	if !ok {
-		return mcp.NewToolResultError("category_id must be a number"), nil
+	entry, err := s.client.CategoryEntry(categoryID, args.EntryID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to fetch category entry: %v", err)), nil
 	}
 
-	entryIDFloat, ok := argsMap["entry_id"].(float64)
-	if !ok {
-		return mcp.NewToolResultError("entry_id must be a number"), nil
-	}
+	return entryResult(entry, s.entryView(args.Format, args.MaxChars, nil, nil)), nil
+}
 
-	categoryID := int64(categoryIDFloat)
-	entryID := int64(entryIDFloat)
+func (s *MinifluxServer) UpdateEntry(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args updateEntryArgs
+	if err := bindArguments(request, &args); err != nil {
+		return argumentErrorResult(err), nil
+	}
 
-	entry, err := s.client.CategoryEntry(categoryID, entryID)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to fetch category entry: %v", err)), nil
+	changes := &client.EntryModificationRequest{
+		Title:   args.Title,
+		Content: args.Content,
+	}
+	if *changes == (client.EntryModificationRequest{}) {
+		return mcp.NewToolResultError("at least one of title or content is required"), nil
 	}
 
-	entryJSON, err := json.MarshalIndent(entry, "", "  ")
+	updatedEntry, err := s.client.UpdateEntry(args.EntryID, changes)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal entry: %v", err)), nil
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to update entry: %v", err)), nil
 	}
 
-	return mcp.NewToolResultText(string(entryJSON)), nil
+	return entryResult(updatedEntry, s.entryView("json", nil, nil, nil)), nil
 }
 
 func (s *MinifluxServer) ToggleStarred(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	args := request.Params.Arguments
-	if args == nil {
-		return mcp.NewToolResultError("entry_id is required"), nil
+	var args entryArgs
+	if err := bindArguments(request, &args); err != nil {
+		return argumentErrorResult(err), nil
 	}
 
-	argsMap, ok := args.(map[string]interface{})
-	if !ok {
-		return mcp.NewToolResultError("Invalid arguments format"), nil
-	}
-
-	entryIDFloat, ok := argsMap["entry_id"].(float64)
-	if !ok {
-		return mcp.NewToolResultError("entry_id must be a number"), nil
-	}
-
-	entryID := int64(entryIDFloat)
-	err := s.client.ToggleStarred(entryID)
+	err := s.client.ToggleStarred(args.EntryID)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to toggle starred status: %v", err)), nil
 	}
 
-	return mcp.NewToolResultText(fmt.Sprintf("Starred status toggled for entry %d", entryID)), nil
+	return mcp.NewToolResultText(fmt.Sprintf("Starred status toggled for entry %d", args.EntryID)), nil
 }
 
 func (s *MinifluxServer) SaveEntry(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	args := request.Params.Arguments
-	if args == nil {
-		return mcp.NewToolResultError("entry_id is required"), nil
-	}
-
-	argsMap, ok := args.(map[string]interface{})
-	if !ok {
-		return mcp.NewToolResultError("Invalid arguments format"), nil
+	var args entryArgs
+	if err := bindArguments(request, &args); err != nil {
+		return argumentErrorResult(err), nil
 	}
 
-	entryIDFloat, ok := argsMap["entry_id"].(float64)
-	if !ok {
-		return mcp.NewToolResultError("entry_id must be a number"), nil
-	}
-
-	entryID := int64(entryIDFloat)
-	err := s.client.SaveEntry(entryID)
+	err := s.client.SaveEntry(args.EntryID)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to save entry: %v", err)), nil
 	}
 
-	return mcp.NewToolResultText(fmt.Sprintf("Entry %d saved successfully", entryID)), nil
+	return mcp.NewToolResultText(fmt.Sprintf("Entry %d saved successfully", args.EntryID)), nil
 }
 
 func (s *MinifluxServer) FetchEntryOriginalContent(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	args := request.Params.Arguments
-	if args == nil {
-		return mcp.NewToolResultError("entry_id is required"), nil
-	}
-
-	argsMap, ok := args.(map[string]interface{})
-	if !ok {
-		return mcp.NewToolResultError("Invalid arguments format"), nil
-	}
-
-	entryIDFloat, ok := argsMap["entry_id"].(float64)
-	if !ok {
-		return mcp.NewToolResultError("entry_id must be a number"), nil
+	var args entryArgs
+	if err := bindArguments(request, &args); err != nil {
+		return argumentErrorResult(err), nil
 	}
 
-	entryID := int64(entryIDFloat)
-	content, err := s.client.FetchEntryOriginalContent(entryID)
+	content, err := s.client.FetchEntryOriginalContent(args.EntryID)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to fetch original content: %v", err)), nil
 	}
@@ -430,32 +329,26 @@ func (s *MinifluxServer) FetchEntryOriginalContent(ctx context.Context, request
 }
 
 func (s *MinifluxServer) MarkAllAsRead(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	args := request.Params.Arguments
-	if args == nil {
-		return mcp.NewToolResultError("user_id is required"), nil
-	}
-
-	argsMap, ok := args.(map[string]interface{})
-	if !ok {
-		return mcp.NewToolResultError("Invalid arguments format"), nil
-	}
-
-	userIDFloat, ok := argsMap["user_id"].(float64)
-	if !ok {
-		return mcp.NewToolResultError("user_id must be a number"), nil
+	var args userArgs
+	if err := bindArguments(request, &args); err != nil {
+		return argumentErrorResult(err), nil
 	}
 
-	userID := int64(userIDFloat)
-	err := s.client.MarkAllAsRead(userID)
+	err := s.client.MarkAllAsRead(args.UserID)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to mark all as read: %v", err)), nil
 	}
 
-	return mcp.NewToolResultText(fmt.Sprintf("All entries marked as read for user %d", userID)), nil
+	return mcp.NewToolResultText(fmt.Sprintf("All entries marked as read for user %d", args.UserID)), nil
 }
 
 // System and Utility Methods
 func (s *MinifluxServer) GetVersion(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args noArgs
+	if err := bindArguments(request, &args); err != nil {
+		return argumentErrorResult(err), nil
+	}
+
 	version, err := s.client.Version()
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to fetch version: %v", err)), nil
@@ -470,6 +363,11 @@ func (s *MinifluxServer) GetVersion(ctx context.Context, request mcp.CallToolReq
 }
 
 func (s *MinifluxServer) Healthcheck(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args noArgs
+	if err := bindArguments(request, &args); err != nil {
+		return argumentErrorResult(err), nil
+	}
+
 	err := s.client.Healthcheck()
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Healthcheck failed: %v", err)), nil
@@ -479,6 +377,11 @@ func (s *MinifluxServer) Healthcheck(ctx context.Context, request mcp.CallToolRe
 }
 
 func (s *MinifluxServer) FetchCounters(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args noArgs
+	if err := bindArguments(request, &args); err != nil {
+		return argumentErrorResult(err), nil
+	}
+
 	counters, err := s.client.FetchCounters()
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to fetch counters: %v", err)), nil
@@ -489,26 +392,16 @@ func (s *MinifluxServer) FetchCounters(ctx context.Context, request mcp.CallTool
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal counters: %v", err)), nil
 	}
 
-	return mcp.NewToolResultText(string(countersJSON)), nil
+	return mcp.NewToolResultStructured(newFeedCounters(counters), string(countersJSON)), nil
 }
 
 func (s *MinifluxServer) Discover(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	args := request.Params.Arguments
-	if args == nil {
-		return mcp.NewToolResultError("url is required"), nil
+	var args discoverArgs
+	if err := bindArguments(request, &args); err != nil {
+		return argumentErrorResult(err), nil
 	}
 
-	argsMap, ok := args.(map[string]interface{})
-	if !ok {
-		return mcp.NewToolResultError("Invalid arguments format"), nil
-	}
-
-	url, ok := argsMap["url"].(string)
-	if !ok {
-		return mcp.NewToolResultError("url must be a string"), nil
-	}
-
-	subscriptions, err := s.client.Discover(url)
+	subscriptions, err := s.client.Discover(args.URL)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to discover feeds: %v", err)), nil
 	}
@@ -522,6 +415,11 @@ func (s *MinifluxServer) Discover(ctx context.Context, request mcp.CallToolReque
 }
 
 func (s *MinifluxServer) Export(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args noArgs
+	if err := bindArguments(request, &args); err != nil {
+		return argumentErrorResult(err), nil
+	}
+
 	data, err := s.client.Export()
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to export: %v", err)), nil
@@ -531,6 +429,11 @@ func (s *MinifluxServer) Export(ctx context.Context, request mcp.CallToolRequest
 }
 
 func (s *MinifluxServer) FlushHistory(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args noArgs
+	if err := bindArguments(request, &args); err != nil {
+		return argumentErrorResult(err), nil
+	}
+
 	err := s.client.FlushHistory()
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to flush history: %v", err)), nil
@@ -541,6 +444,11 @@ func (s *MinifluxServer) FlushHistory(ctx context.Context, request mcp.CallToolR
 
 // API Key Management Methods
 func (s *MinifluxServer) GetAPIKeys(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args noArgs
+	if err := bindArguments(request, &args); err != nil {
+		return argumentErrorResult(err), nil
+	}
+
 	apiKeys, err := s.client.APIKeys()
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to fetch API keys: %v", err)), nil
@@ -551,26 +459,16 @@ func (s *MinifluxServer) GetAPIKeys(ctx context.Context, request mcp.CallToolReq
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API keys: %v", err)), nil
 	}
 
-	return mcp.NewToolResultText(string(apiKeysJSON)), nil
+	return mcp.NewToolResultStructured(apiKeyList{APIKeys: apiKeys}, string(apiKeysJSON)), nil
 }
 
 func (s *MinifluxServer) CreateAPIKey(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	args := request.Params.Arguments
-	if args == nil {
-		return mcp.NewToolResultError("description is required"), nil
-	}
-
-	argsMap, ok := args.(map[string]interface{})
-	if !ok {
-		return mcp.NewToolResultError("Invalid arguments format"), nil
-	}
-
-	description, ok := argsMap["description"].(string)
-	if !ok {
-		return mcp.NewToolResultError("description must be a string"), nil
+	var args createAPIKeyArgs
+	if err := bindArguments(request, &args); err != nil {
+		return argumentErrorResult(err), nil
 	}
 
-	apiKey, err := s.client.CreateAPIKey(description)
+	apiKey, err := s.client.CreateAPIKey(args.Description)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to create API key: %v", err)), nil
 	}
@@ -580,53 +478,31 @@ func (s *MinifluxServer) CreateAPIKey(ctx context.Context, request mcp.CallToolR
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API key: %v", err)), nil
 	}
 
-	return mcp.NewToolResultText(string(apiKeyJSON)), nil
+	return mcp.NewToolResultStructured(apiKey, string(apiKeyJSON)), nil
 }
 
 func (s *MinifluxServer) DeleteAPIKey(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	args := request.Params.Arguments
-	if args == nil {
-		return mcp.NewToolResultError("api_key_id is required"), nil
-	}
-
-	argsMap, ok := args.(map[string]interface{})
-	if !ok {
-		return mcp.NewToolResultError("Invalid arguments format"), nil
+	var args apiKeyArgs
+	if err := bindArguments(request, &args); err != nil {
+		return argumentErrorResult(err), nil
 	}
 
-	apiKeyIDFloat, ok := argsMap["api_key_id"].(float64)
-	if !ok {
-		return mcp.NewToolResultError("api_key_id must be a number"), nil
-	}
-
-	apiKeyID := int64(apiKeyIDFloat)
-	err := s.client.DeleteAPIKey(apiKeyID)
+	err := s.client.DeleteAPIKey(args.APIKeyID)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to delete API key: %v", err)), nil
 	}
 
-	return mcp.NewToolResultText(fmt.Sprintf("API key %d deleted successfully", apiKeyID)), nil
+	return mcp.NewToolResultText(fmt.Sprintf("API key %d deleted successfully", args.APIKeyID)), nil
 }
 
 // Icon Methods
 func (s *MinifluxServer) GetIcon(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	args := request.Params.Arguments
-	if args == nil {
-		return mcp.NewToolResultError("icon_id is required"), nil
-	}
-
-	argsMap, ok := args.(map[string]interface{})
-	if !ok {
-		return mcp.NewToolResultError("Invalid arguments format"), nil
+	var args iconArgs
+	if err := bindArguments(request, &args); err != nil {
+		return argumentErrorResult(err), nil
 	}
 
-	iconIDFloat, ok := argsMap["icon_id"].(float64)
-	if !ok {
-		return mcp.NewToolResultError("icon_id must be a number"), nil
-	}
-
-	iconID := int64(iconIDFloat)
-	icon, err := s.client.Icon(iconID)
+	icon, err := s.client.Icon(args.IconID)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to fetch icon: %v", err)), nil
 	}
@@ -641,23 +517,12 @@ func (s *MinifluxServer) GetIcon(ctx context.Context, request mcp.CallToolReques
 
 // Enclosure Methods
 func (s *MinifluxServer) GetEnclosure(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	args := request.Params.Arguments
-	if args == nil {
-		return mcp.NewToolResultError("enclosure_id is required"), nil
-	}
-
-	argsMap, ok := args.(map[string]interface{})
-	if !ok {
-		return mcp.NewToolResultError("Invalid arguments format"), nil
-	}
-
-	enclosureIDFloat, ok := argsMap["enclosure_id"].(float64)
-	if !ok {
-		return mcp.NewToolResultError("enclosure_id must be a number"), nil
+	var args enclosureArgs
+	if err := bindArguments(request, &args); err != nil {
+		return argumentErrorResult(err), nil
 	}
 
-	enclosureID := int64(enclosureIDFloat)
-	enclosure, err := s.client.Enclosure(enclosureID)
+	enclosure, err := s.client.Enclosure(args.EnclosureID)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to fetch enclosure: %v", err)), nil
 	}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -137,3 +138,229 @@ func TestToggleStarredUsesStarEndpoint(t *testing.T) {
 		t.Fatalf("ToggleStarred returned tool error: %#v", result.Content)
 	}
 }
+
+func TestUpdateEntry(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/v1/entries/9" {
+			t.Errorf("request = %s %s, want PUT /v1/entries/9", r.Method, r.URL.Path)
+		}
+
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode request body: %v", err)
+		}
+		if body["title"] != "Fixed title" || body["content"] != nil {
+			t.Errorf("body = %#v, want only the title", body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(&client.Entry{ID: 9, Title: "Fixed title"})
+	}))
+	defer apiServer.Close()
+
+	minifluxServer := &MinifluxServer{client: client.NewClient(apiServer.URL, "test-api-key")}
+	request := mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Arguments: map[string]interface{}{
+				"entry_id": float64(9),
+				"title":    "Fixed title",
+			},
+		},
+	}
+
+	result, err := minifluxServer.UpdateEntry(context.Background(), request)
+	if err != nil {
+		t.Fatalf("UpdateEntry returned error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("UpdateEntry returned tool error: %#v", result.Content)
+	}
+	if document := result.StructuredContent.(*entryDocument); document.Title != "Fixed title" {
+		t.Errorf("updated entry = %#v", document.Entry)
+	}
+
+	request.Params.Arguments = map[string]interface{}{"entry_id": float64(9)}
+	result, err = minifluxServer.UpdateEntry(context.Background(), request)
+	if err != nil || !result.IsError {
+		t.Errorf("UpdateEntry without changes = %#v, %v, want a tool error", result, err)
+	}
+}
+
+func TestUpdateUserReportsChanges(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/users/3" {
+			t.Errorf("path = %s, want /v1/users/3", r.URL.Path)
+		}
+		user := client.User{ID: 3, Username: "alice", Theme: "light_serif", EntriesPerPage: 100, Timezone: "UTC"}
+		if r.Method == http.MethodPut {
+			var body map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("decode request body: %v", err)
+			}
+			if body["theme"] != "dark_serif" || body["entries_per_page"] != float64(50) || body["timezone"] != nil {
+				t.Errorf("body = %#v, want only theme and entries_per_page", body)
+			}
+			user.Theme, user.EntriesPerPage = "dark_serif", 50
+			w.WriteHeader(http.StatusCreated)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(&user)
+	}))
+	defer apiServer.Close()
+
+	minifluxServer := &MinifluxServer{client: client.NewClient(apiServer.URL, "test-api-key")}
+	request := mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Arguments: map[string]interface{}{
+				"user_id":          float64(3),
+				"theme":            "dark_serif",
+				"entries_per_page": float64(50),
+			},
+		},
+	}
+
+	result, err := minifluxServer.UpdateUser(context.Background(), request)
+	if err != nil {
+		t.Fatalf("UpdateUser returned error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("UpdateUser returned tool error: %#v", result.Content)
+	}
+	update := result.StructuredContent.(*userUpdate)
+	if len(update.Changes) != 2 || update.Changes["theme"] != (fieldChange{Before: "light_serif", After: "dark_serif"}) {
+		t.Errorf("changes = %#v, want theme and entries_per_page", update.Changes)
+	}
+	want := "User 3 (alice) updated:\n- entries_per_page: 100\n+ entries_per_page: 50\n- theme: \"light_serif\"\n+ theme: \"dark_serif\""
+	if text := result.Content[0].(mcp.TextContent).Text; text != want {
+		t.Errorf("diff = %q, want %q", text, want)
+	}
+
+	request.Params.Arguments = map[string]interface{}{"user_id": float64(3), "theme": "blue"}
+	result, err = minifluxServer.UpdateUser(context.Background(), request)
+	if err != nil || !result.IsError {
+		t.Errorf("UpdateUser with an unknown theme = %#v, %v, want a tool error", result, err)
+	}
+}
+
+func TestUpdateUserAsNonAdmin(t *testing.T) {
+	var updated []string
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user := client.User{ID: 3, Username: "alice", Theme: "light_serif"}
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/me":
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/v1/users/"):
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"error_message":"Access Forbidden"}`))
+			return
+		case r.Method == http.MethodPut && r.URL.Path == "/v1/users/3":
+			updated = append(updated, r.URL.Path)
+			user.Theme = "dark_serif"
+			w.WriteHeader(http.StatusCreated)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(&user)
+	}))
+	defer apiServer.Close()
+	minifluxServer := &MinifluxServer{client: client.NewClient(apiServer.URL, "test-api-key")}
+
+	call := func(userID float64) *mcp.CallToolResult {
+		t.Helper()
+		request := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]interface{}{"user_id": userID, "theme": "dark_serif"}}}
+		result, err := minifluxServer.UpdateUser(context.Background(), request)
+		if err != nil {
+			t.Fatalf("UpdateUser returned error: %v", err)
+		}
+		return result
+	}
+
+	result := call(3)
+	if result.IsError {
+		t.Fatalf("UpdateUser of the current user = %#v, want success", result.Content)
+	}
+	if update := result.StructuredContent.(*userUpdate); update.Changes["theme"] != (fieldChange{Before: "light_serif", After: "dark_serif"}) {
+		t.Errorf("changes = %#v, want the theme changed", update.Changes)
+	}
+
+	if result := call(4); !result.IsError || len(updated) != 1 {
+		t.Errorf("UpdateUser of another user = %#v, updated %v, want a forbidden error and no update", result, updated)
+	}
+}
+
+func TestCategoryOptions(t *testing.T) {
+	var bodies []map[string]interface{}
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode request body: %v", err)
+		}
+		bodies = append(bodies, body)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(&client.Category{ID: 7, Title: "News", HideGlobally: true})
+	}))
+	defer apiServer.Close()
+
+	minifluxServer := &MinifluxServer{client: client.NewClient(apiServer.URL, "test-api-key")}
+	create := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]interface{}{"title": "News", "hide_globally": true}}}
+	if result, err := minifluxServer.CreateCategory(context.Background(), create); err != nil || result.IsError {
+		t.Fatalf("CreateCategory = %#v, %v", result, err)
+	}
+	update := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]interface{}{"category_id": float64(7), "hide_globally": false}}}
+	if result, err := minifluxServer.UpdateCategory(context.Background(), update); err != nil || result.IsError {
+		t.Fatalf("UpdateCategory = %#v, %v", result, err)
+	}
+
+	if len(bodies) != 2 || bodies[0]["hide_globally"] != true || bodies[1]["hide_globally"] != false || bodies[1]["title"] != nil {
+		t.Errorf("request bodies = %#v, want hide_globally set on create and cleared alone on update", bodies)
+	}
+}
+
+func TestCreateFeed(t *testing.T) {
+	var bodies []map[string]interface{}
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/categories":
+			_ = json.NewEncoder(w).Encode(client.Categories{{ID: 5, Title: "News"}, {ID: 3, Title: "All"}, {ID: 9, Title: "Science"}})
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/feeds":
+			var body map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("decode request body: %v", err)
+			}
+			bodies = append(bodies, body)
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(map[string]int64{"feed_id": 42})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	defer apiServer.Close()
+
+	minifluxServer := &MinifluxServer{client: client.NewClient(apiServer.URL, "test-api-key")}
+	for _, arguments := range []map[string]interface{}{
+		{"feed_url": "https://example.com/feed.xml", "scraper_rules": "article", "hide_globally": true, "disable_http2": true, "proxy_url": "http://proxy:3128"},
+		{"feed_url": "https://example.com/other.xml", "category": "science"},
+	} {
+		result, err := minifluxServer.CreateFeed(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: arguments}})
+		if err != nil || result.IsError {
+			t.Fatalf("CreateFeed(%v) = %#v, %v", arguments, result, err)
+		}
+	}
+
+	if len(bodies) != 2 {
+		t.Fatalf("created %d feeds, want 2", len(bodies))
+	}
+	first := bodies[0]
+	if first["category_id"] != float64(0) || first["scraper_rules"] != "article" || first["hide_globally"] != true || first["disable_http2"] != true || first["proxy_url"] != "http://proxy:3128" {
+		t.Errorf("first feed = %#v, want the options forwarded without a category, leaving it to Miniflux", first)
+	}
+	if bodies[1]["category_id"] != float64(9) {
+		t.Errorf("second feed category_id = %#v, want 9", bodies[1]["category_id"])
+	}
+}
@@ -0,0 +1,279 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"miniflux.app/v2/client"
+)
+
+// Problems feed_health reports, in the order they are listed for a feed.
+const (
+	feedDisabled     = "disabled"
+	feedNeverChecked = "never_checked"
+	feedErroring     = "erroring"
+	feedStale        = "stale"
+	feedOverdue      = "overdue"
+)
+
+// Actions feed_health suggests.
+const (
+	actionEnable     = "enable"
+	actionRefresh    = "refresh"
+	actionRediscover = "rediscover"
+	actionDisable    = "disable"
+)
+
+const (
+	// persistentErrorCount is the number of consecutive parsing errors from
+	// which a refresh is no longer expected to help.
+	persistentErrorCount = 3
+	// feedOverdueAfter is how long past its next check a feed may go before
+	// it is reported as overdue.
+	feedOverdueAfter = 24 * time.Hour
+	// recentEntryPages bounds the pages of recent entries read to date the
+	// latest entry of many feeds at once; the feeds not found in them are
+	// looked up one by one.
+	recentEntryPages = 4
+)
+
+// feedHealthReport is the result of feed_health. Feeds lists the feeds with
+// problems, and the healthy ones too when requested.
+type feedHealthReport struct {
+	StaleDays  int              `json:"stale_days"`
+	Summary    healthCounts     `json:"summary"`
+	Categories []categoryHealth `json:"categories"`
+	Feeds      []feedHealth     `json:"feeds"`
+}
+
+// healthCounts counts feeds by problem; a feed with several problems is
+// counted once for each.
+type healthCounts struct {
+	Feeds    int            `json:"feeds"`
+	Healthy  int            `json:"healthy"`
+	Problems map[string]int `json:"problems"`
+}
+
+type categoryHealth struct {
+	CategoryID int64  `json:"category_id"`
+	Title      string `json:"title"`
+	healthCounts
+}
+
+// feedHealth is the assessment of one feed. LastEntryAt is the publication
+// date of its latest entry, unset when the feed has none or was not looked
+// at because it is disabled or never checked.
+type feedHealth struct {
+	FeedID              int64      `json:"feed_id"`
+	Title               string     `json:"title"`
+	FeedURL             string     `json:"feed_url"`
+	SiteURL             string     `json:"site_url,omitempty"`
+	CategoryID          int64      `json:"category_id,omitempty"`
+	Category            string     `json:"category,omitempty"`
+	Problems            []string   `json:"problems"`
+	Actions             []string   `json:"actions"`
+	Suggestion          string     `json:"suggestion,omitempty"`
+	CheckedAt           *time.Time `json:"checked_at,omitempty"`
+	LastEntryAt         *time.Time `json:"last_entry_at,omitempty"`
+	ParsingErrorCount   int        `json:"parsing_error_count,omitempty"`
+	ParsingErrorMessage string     `json:"parsing_error_message,omitempty"`
+}
+
+func (s *MinifluxServer) FeedHealth(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args feedHealthArgs
+	if err := bindArguments(request, &args); err != nil {
+		return argumentErrorResult(err), nil
+	}
+
+	var feeds client.Feeds
+	var categoryID int64
+	if args.categoryReference.given() {
+		var err error
+		if categoryID, err = s.resolveCategory(args.categoryReference); err != nil {
+			return argumentErrorResult(err), nil
+		}
+		if feeds, err = s.client.CategoryFeeds(categoryID); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to fetch category feeds: %v", err)), nil
+		}
+	} else {
+		var err error
+		if feeds, err = s.client.Feeds(); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to fetch feeds: %v", err)), nil
+		}
+	}
+
+	now := time.Now()
+	lastEntryDates, err := s.lastEntryDates(ctx, feeds, categoryID, now.AddDate(0, 0, -args.StaleDays))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to assess feeds: %v", err)), nil
+	}
+
+	report := feedHealthReport{
+		StaleDays:  args.StaleDays,
+		Summary:    healthCounts{Problems: map[string]int{}},
+		Categories: []categoryHealth{},
+		Feeds:      []feedHealth{},
+	}
+	categories := map[int64]*categoryHealth{}
+	for _, feed := range feeds {
+		var lastEntryAt *time.Time
+		if date, ok := lastEntryDates[feed.ID]; ok {
+			lastEntryAt = &date
+		}
+		health := assessFeedHealth(feed, lastEntryAt, now, args.StaleDays)
+
+		category, ok := categories[health.CategoryID]
+		if !ok {
+			category = &categoryHealth{CategoryID: health.CategoryID, Title: health.Category, healthCounts: healthCounts{Problems: map[string]int{}}}
+			categories[health.CategoryID] = category
+		}
+		report.Summary.add(health)
+		category.add(health)
+		if len(health.Problems) > 0 || args.IncludeHealthy {
+			report.Feeds = append(report.Feeds, health)
+		}
+	}
+	for _, category := range categories {
+		report.Categories = append(report.Categories, *category)
+	}
+	slices.SortFunc(report.Categories, func(a, b categoryHealth) int {
+		return strings.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
+	})
+
+	reportJSON, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal feed health: %v", err)), nil
+	}
+	return mcp.NewToolResultStructured(report, string(reportJSON)), nil
+}
+
+// lastEntryDates returns the publication date of the latest entry of the
+// enabled and checked feeds among feeds, leaving out those without entries.
+// The first recentEntryPages pages of entries published since cutoff cover
+// the busy feeds at once; the others are looked up one by one.
+func (s *MinifluxServer) lastEntryDates(ctx context.Context, feeds client.Feeds, categoryID int64, cutoff time.Time) (map[int64]time.Time, error) {
+	wanted := map[int64]bool{}
+	for _, feed := range feeds {
+		if !feed.Disabled && !feed.CheckedAt.IsZero() {
+			wanted[feed.ID] = true
+		}
+	}
+
+	dates := make(map[int64]time.Time, len(wanted))
+	page := client.Filter{CategoryID: categoryID, PublishedAfter: cutoff.Unix(), Order: "published_at", Direction: "desc", Limit: bulkPageSize}
+	for pages := 0; pages < recentEntryPages && len(dates) < len(wanted); pages++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		entries, err := s.client.Entries(&page)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch recent entries: %w", err)
+		}
+		for _, entry := range entries.Entries {
+			if _, seen := dates[entry.FeedID]; wanted[entry.FeedID] && !seen {
+				dates[entry.FeedID] = entry.Date
+			}
+		}
+		if len(entries.Entries) < bulkPageSize {
+			break
+		}
+		page.Offset += len(entries.Entries)
+	}
+
+	for _, feed := range feeds {
+		if _, seen := dates[feed.ID]; !wanted[feed.ID] || seen {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		entries, err := s.client.Entries(&client.Filter{FeedID: feed.ID, Order: "published_at", Direction: "desc", Limit: 1})
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch the latest entry of feed %d: %w", feed.ID, err)
+		}
+		if len(entries.Entries) > 0 {
+			dates[feed.ID] = entries.Entries[0].Date
+		}
+	}
+	return dates, nil
+}
+
+func (c *healthCounts) add(health feedHealth) {
+	c.Feeds++
+	if len(health.Problems) == 0 {
+		c.Healthy++
+	}
+	for _, problem := range health.Problems {
+		c.Problems[problem]++
+	}
+}
+
+// assessFeedHealth classifies a feed and suggests how to fix its problems.
+// lastEntryAt is the publication date of its latest entry, nil when it has
+// none; a feed without entries for staleDays days is stale.
+func assessFeedHealth(feed *client.Feed, lastEntryAt *time.Time, now time.Time, staleDays int) feedHealth {
+	health := feedHealth{
+		FeedID:              feed.ID,
+		Title:               feed.Title,
+		FeedURL:             feed.FeedURL,
+		SiteURL:             feed.SiteURL,
+		Problems:            []string{},
+		Actions:             []string{},
+		LastEntryAt:         lastEntryAt,
+		ParsingErrorCount:   feed.ParsingErrorCount,
+		ParsingErrorMessage: feed.ParsingErrorMsg,
+	}
+	if feed.Category != nil {
+		health.CategoryID, health.Category = feed.Category.ID, feed.Category.Title
+	}
+	if !feed.CheckedAt.IsZero() {
+		checkedAt := feed.CheckedAt
+		health.CheckedAt = &checkedAt
+	}
+
+	var suggestions []string
+	report := func(problem, suggestion string, actions ...string) {
+		health.Problems = append(health.Problems, problem)
+		suggestions = append(suggestions, suggestion)
+		for _, action := range actions {
+			if !slices.Contains(health.Actions, action) {
+				health.Actions = append(health.Actions, action)
+			}
+		}
+	}
+
+	switch {
+	case feed.Disabled:
+		report(feedDisabled, "Fetching is disabled; enable the feed with update_feed if it is still wanted, or delete it.", actionEnable)
+	case feed.CheckedAt.IsZero():
+		report(feedNeverChecked, "The feed was never fetched; refresh it to get its entries.", actionRefresh)
+	}
+	switch {
+	case feed.ParsingErrorCount >= persistentErrorCount:
+		report(feedErroring, fmt.Sprintf("The last %d fetches failed (%s); look for a new feed URL on the site with discover, or disable the feed if the site is gone.", feed.ParsingErrorCount, feed.ParsingErrorMsg), actionRediscover, actionDisable)
+	case feed.ParsingErrorCount > 0:
+		report(feedErroring, fmt.Sprintf("The last fetch failed (%s); refresh the feed to see whether the error persists.", feed.ParsingErrorMsg), actionRefresh)
+	}
+	if feed.Disabled || feed.CheckedAt.IsZero() {
+		health.Suggestion = strings.Join(suggestions, " ")
+		return health
+	}
+
+	cutoff := now.AddDate(0, 0, -staleDays)
+	switch {
+	case lastEntryAt == nil:
+		report(feedStale, "The feed has no entries; check the site with discover for a working feed URL, or disable the feed.", actionRediscover, actionDisable)
+	case lastEntryAt.Before(cutoff):
+		report(feedStale, fmt.Sprintf("No entry was published in the last %d days; the site may have moved its feed, so check it with discover, or disable the feed if the site stopped publishing.", staleDays), actionRediscover, actionDisable)
+	}
+	if !feed.NextCheckAt.IsZero() && now.Sub(feed.NextCheckAt) > feedOverdueAfter {
+		report(feedOverdue, fmt.Sprintf("The feed was due for a check on %s but was not fetched since; refresh it.", feed.NextCheckAt.Format(time.DateOnly)), actionRefresh)
+	}
+	health.Suggestion = strings.Join(suggestions, " ")
+	return health
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"miniflux.app/v2/client"
+)
+
+func TestAssessFeedHealth(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	checked := now.Add(-time.Hour)
+	recent := now.AddDate(0, 0, -3)
+	old := now.AddDate(0, 0, -90)
+
+	testCases := []struct {
+		name        string
+		feed        client.Feed
+		lastEntryAt *time.Time
+		problems    []string
+		actions     []string
+	}{
+		{"healthy", client.Feed{CheckedAt: checked, NextCheckAt: now.Add(time.Hour)}, &recent, []string{}, []string{}},
+		{"disabled", client.Feed{Disabled: true, CheckedAt: checked}, nil, []string{feedDisabled}, []string{actionEnable}},
+		{"never checked", client.Feed{}, nil, []string{feedNeverChecked}, []string{actionRefresh}},
+		{"failed once", client.Feed{CheckedAt: checked, ParsingErrorCount: 1, ParsingErrorMsg: "timeout"}, &recent, []string{feedErroring}, []string{actionRefresh}},
+		{
+			"failing", client.Feed{CheckedAt: checked, ParsingErrorCount: 12, ParsingErrorMsg: "404 Not Found"}, &old,
+			[]string{feedErroring, feedStale}, []string{actionRediscover, actionDisable},
+		},
+		{"stale", client.Feed{CheckedAt: checked}, &old, []string{feedStale}, []string{actionRediscover, actionDisable}},
+		{"empty", client.Feed{CheckedAt: checked}, nil, []string{feedStale}, []string{actionRediscover, actionDisable}},
+		{"overdue", client.Feed{CheckedAt: now.AddDate(0, 0, -5), NextCheckAt: now.AddDate(0, 0, -4)}, &recent, []string{feedOverdue}, []string{actionRefresh}},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			health := assessFeedHealth(&testCase.feed, testCase.lastEntryAt, now, 30)
+			if !slices.Equal(health.Problems, testCase.problems) || !slices.Equal(health.Actions, testCase.actions) {
+				t.Errorf("health = problems %v, actions %v; want %v, %v", health.Problems, health.Actions, testCase.problems, testCase.actions)
+			}
+			if (len(health.Problems) == 0) != (health.Suggestion == "") {
+				t.Errorf("suggestion = %q for problems %v", health.Suggestion, health.Problems)
+			}
+		})
+	}
+}
+
+func TestFeedHealth(t *testing.T) {
+	now := time.Now()
+	news := &client.Category{ID: 1, Title: "News"}
+	blogs := &client.Category{ID: 2, Title: "blogs"}
+	feeds := client.Feeds{
+		{ID: 10, Title: "Daily", Category: news, CheckedAt: now, NextCheckAt: now.Add(time.Hour)},
+		{ID: 11, Title: "Gone", Category: news, CheckedAt: now, ParsingErrorCount: 4, ParsingErrorMsg: "404 Not Found"},
+		{ID: 20, Title: "Quiet", Category: blogs, CheckedAt: now},
+		{ID: 21, Title: "Paused", Category: blogs, Disabled: true},
+	}
+	latest := map[int64]time.Time{10: now.AddDate(0, 0, -1), 11: now.AddDate(0, 0, -2), 20: now.AddDate(0, 0, -45), 21: now}
+	var lookedUp []int64
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response any
+		switch r.URL.Path {
+		case "/v1/feeds":
+			response = feeds
+		case "/v1/categories":
+			response = client.Categories{news, blogs}
+		case "/v1/categories/2/feeds":
+			response = client.Feeds{feeds[2], feeds[3]}
+		case "/v1/entries":
+			query := r.URL.Query()
+			feedID, _ := strconv.ParseInt(query.Get("feed_id"), 10, 64)
+			categoryID, _ := strconv.ParseInt(query.Get("category_id"), 10, 64)
+			publishedAfter, _ := strconv.ParseInt(query.Get("published_after"), 10, 64)
+			lookedUp = append(lookedUp, feedID)
+			result := client.EntryResultSet{Entries: client.Entries{}}
+			for _, feed := range feeds {
+				date, ok := latest[feed.ID]
+				if !ok || (feedID != 0 && feed.ID != feedID) || (categoryID != 0 && feed.Category.ID != categoryID) || date.Unix() <= publishedAfter {
+					continue
+				}
+				result.Entries = append(result.Entries, &client.Entry{ID: feed.ID * 100, FeedID: feed.ID, Date: date})
+			}
+			if query.Get("order") == "published_at" {
+				slices.SortFunc(result.Entries, func(a, b *client.Entry) int { return b.Date.Compare(a.Date) })
+			}
+			result.Total = len(result.Entries)
+			response = result
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	defer apiServer.Close()
+	minifluxServer := &MinifluxServer{client: client.NewClient(apiServer.URL, "test-api-key")}
+
+	call := func(arguments map[string]any) feedHealthReport {
+		t.Helper()
+		result, err := minifluxServer.FeedHealth(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: arguments}})
+		if err != nil || result.IsError {
+			t.Fatalf("FeedHealth = %#v, %v", result, err)
+		}
+		return result.StructuredContent.(feedHealthReport)
+	}
+
+	report := call(nil)
+	if report.StaleDays != 30 || report.Summary.Feeds != 4 || report.Summary.Healthy != 1 {
+		t.Errorf("summary = %+v, want 4 feeds of which 1 healthy", report.Summary)
+	}
+	if report.Summary.Problems[feedErroring] != 1 || report.Summary.Problems[feedStale] != 1 || report.Summary.Problems[feedDisabled] != 1 {
+		t.Errorf("problems = %v, want one erroring, stale and disabled feed", report.Summary.Problems)
+	}
+	var listed []int64
+	for _, feed := range report.Feeds {
+		listed = append(listed, feed.FeedID)
+	}
+	if !slices.Equal(listed, []int64{11, 20, 21}) {
+		t.Errorf("listed feeds = %v, want only the unhealthy 11, 20 and 21", listed)
+	}
+	if len(report.Categories) != 2 || report.Categories[0].Title != "blogs" || report.Categories[0].Healthy != 0 || report.Categories[1].Healthy != 1 {
+		t.Errorf("categories = %+v, want blogs without healthy feeds, then News with one", report.Categories)
+	}
+	if !slices.Equal(lookedUp, []int64{0, 20}) {
+		t.Errorf("entries fetched for feeds %v, want one query for all feeds and one for the stale feed 20", lookedUp)
+	}
+	if report.Feeds[2].LastEntryAt != nil {
+		t.Errorf("disabled feed = %+v, want no latest entry looked at", report.Feeds[2])
+	}
+
+	lookedUp = nil
+	report = call(map[string]any{"category": "blogs", "stale_days": float64(60), "include_healthy": true})
+	if report.Summary.Feeds != 2 || report.Summary.Healthy != 1 || len(report.Feeds) != 2 || len(report.Categories) != 1 {
+		t.Errorf("blogs report = %+v, want feed 20 healthy with a 60 day threshold and both feeds listed", report)
+	}
+	if !slices.Equal(lookedUp, []int64{0}) {
+		t.Errorf("entries fetched for feeds %v, want a single query covering feed 20", lookedUp)
+	}
+}
+
+func TestLastEntryDatesBoundsRecentEntries(t *testing.T) {
+	now := time.Now()
+	var pages int
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		result := client.EntryResultSet{Entries: client.Entries{}}
+		if r.URL.Query().Get("feed_id") == "" {
+			// A busy feed filling every page of recent entries.
+			pages++
+			for range bulkPageSize {
+				result.Entries = append(result.Entries, &client.Entry{FeedID: 10, Date: now})
+			}
+		} else {
+			result.Entries = client.Entries{{FeedID: 11, Date: now.AddDate(-1, 0, 0)}}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(result)
+	}))
+	defer apiServer.Close()
+	minifluxServer := &MinifluxServer{client: client.NewClient(apiServer.URL, "test-api-key")}
+
+	feeds := client.Feeds{{ID: 10, CheckedAt: now}, {ID: 11, CheckedAt: now}}
+	dates, err := minifluxServer.lastEntryDates(context.Background(), feeds, 0, now.AddDate(0, 0, -30))
+	if err != nil {
+		t.Fatalf("lastEntryDates: %v", err)
+	}
+	if pages != recentEntryPages || len(dates) != 2 || !dates[11].Before(now.AddDate(0, 0, -30)) {
+		t.Errorf("read %d pages of recent entries with dates %v, want %d pages and feed 11 looked up on its own", pages, dates, recentEntryPages)
+	}
+}
@@ -3,9 +3,12 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
 	"os"
+	"reflect"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -14,6 +17,10 @@ import (
 
 type MinifluxServer struct {
 	client *client.Client
+	tools  toolConfig
+	// subscriptions is nil when the transport cannot deliver resource
+	// update notifications.
+	subscriptions *resourceSubscriptions
 }
 
 func NewMinifluxServer() *MinifluxServer {
@@ -56,220 +63,195 @@ func NewMinifluxServer() *MinifluxServer {
 }
 
 func (s *MinifluxServer) GetFeeds(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	feeds, err := s.client.Feeds()
+	var args feedsArgs
+	if err := bindArguments(request, &args); err != nil {
+		return argumentErrorResult(err), nil
+	}
+	fields, err := parseFieldSelection(args.Fields, reflect.TypeFor[client.Feed]())
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to fetch feeds: %v", err)), nil
+		return argumentErrorResult(err), nil
 	}
 
-	feedsJSON, err := json.MarshalIndent(feeds, "", "  ")
+	feeds, err := s.client.Feeds()
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal feeds: %v", err)), nil
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to fetch feeds: %v", err)), nil
 	}
 
-	return mcp.NewToolResultText(string(feedsJSON)), nil
+	return feedsResult(feeds, fields), nil
 }
 
 func (s *MinifluxServer) GetEntries(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	args := request.Params.Arguments
+	var args entriesArgs
+	if err := bindArguments(request, &args); err != nil {
+		return argumentErrorResult(err), nil
+	}
+	fields, err := entryFieldSelection(args.Fields, args.Format)
+	if err != nil {
+		return argumentErrorResult(err), nil
+	}
 
-	// Parse optional parameters
 	var filter *client.Filter
-	if args != nil {
-		argsMap, ok := args.(map[string]interface{})
-		if ok {
-			filter = &client.Filter{}
-
-			if statusValues, ok := argsMap["statuses"].([]interface{}); ok {
-				for _, statusValue := range statusValues {
-					if status, ok := statusValue.(string); ok {
-						filter.Statuses = append(filter.Statuses, status)
-					}
-				}
-			}
-
-			if statusStr, ok := argsMap["status"].(string); ok && len(filter.Statuses) == 0 {
-				filter.Status = statusStr
-			}
-
-			if feedIDFloat, ok := argsMap["feed_id"].(float64); ok {
-				filter.FeedID = int64(feedIDFloat)
-			}
-
-			if categoryIDFloat, ok := argsMap["category_id"].(float64); ok {
-				filter.CategoryID = int64(categoryIDFloat)
-			}
-
-			if limitFloat, ok := argsMap["limit"].(float64); ok {
-				filter.Limit = int(limitFloat)
-			}
-
-			if offsetFloat, ok := argsMap["offset"].(float64); ok {
-				filter.Offset = int(offsetFloat)
-			}
-
-			if publishedAfterFloat, ok := argsMap["published_after"].(float64); ok {
-				filter.PublishedAfter = int64(publishedAfterFloat)
-			}
-
-			if publishedBeforeFloat, ok := argsMap["published_before"].(float64); ok {
-				filter.PublishedBefore = int64(publishedBeforeFloat)
-			}
-
-			if changedAfterFloat, ok := argsMap["changed_after"].(float64); ok {
-				filter.ChangedAfter = int64(changedAfterFloat)
-			}
-
-			if changedBeforeFloat, ok := argsMap["changed_before"].(float64); ok {
-				filter.ChangedBefore = int64(changedBeforeFloat)
-			}
-
-			if beforeEntryIDFloat, ok := argsMap["before_entry_id"].(float64); ok {
-				filter.BeforeEntryID = int64(beforeEntryIDFloat)
-			}
-
-			if afterEntryIDFloat, ok := argsMap["after_entry_id"].(float64); ok {
-				filter.AfterEntryID = int64(afterEntryIDFloat)
-			}
-
-			if search, ok := argsMap["search"].(string); ok {
-				filter.Search = search
-			}
-
-			if starred, ok := argsMap["starred"].(bool); ok {
-				if starred {
-					filter.Starred = client.FilterOnlyStarred
-				} else {
-					filter.Starred = client.FilterNotStarred
-				}
-			}
-
-			if order, ok := argsMap["order"].(string); ok {
-				filter.Order = order
-			}
-
-			if direction, ok := argsMap["direction"].(string); ok {
-				filter.Direction = direction
-			}
-
-			if globallyVisible, ok := argsMap["globally_visible"].(bool); ok {
-				filter.GloballyVisible = globallyVisible
-			}
-		}
+	if args.Cursor != nil {
+		filter, err = entryFilterFromCursor(request, *args.Cursor, args.Limit, "get_entries", 0)
+	} else {
+		filter, err = s.entriesFilter(args)
+	}
+	if err != nil {
+		return argumentErrorResult(err), nil
 	}
 
 	entries, err := s.client.Entries(filter)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to fetch entries: %v", err)), nil
 	}
-
-	entriesJSON, err := json.MarshalIndent(entries, "", "  ")
+	pagination, err := paginateEntries("get_entries", 0, filter, entries, s.client.Entries)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal entries: %v", err)), nil
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to fetch entries: %v", err)), nil
 	}
 
-	return mcp.NewToolResultText(string(entriesJSON)), nil
+	return entriesResult(entries, s.entryView(args.Format, args.MaxChars, fields, &pagination)), nil
 }
 
-func (s *MinifluxServer) GetEntry(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	args := request.Params.Arguments
-	if args == nil {
-		return mcp.NewToolResultError("entry_id is required"), nil
+// entriesFilter builds the Miniflux filter of get_entries from its arguments.
+func (s *MinifluxServer) entriesFilter(args entriesArgs) (*client.Filter, error) {
+	filter, err := s.entryFilter(args.entryFilterArgs)
+	if err != nil {
+		return nil, err
 	}
-
-	argsMap, ok := args.(map[string]interface{})
-	if !ok {
-		return mcp.NewToolResultError("Invalid arguments format"), nil
+	filter.Offset = args.Offset
+	if args.Status != nil && len(filter.Statuses) == 0 {
+		filter.Status = *args.Status
 	}
-
-	entryIDFloat, ok := argsMap["entry_id"].(float64)
-	if !ok {
-		return mcp.NewToolResultError("entry_id must be a number"), nil
+	if args.Limit != nil {
+		filter.Limit = *args.Limit
 	}
-
-	entryID := int64(entryIDFloat)
-	entry, err := s.client.Entry(entryID)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to fetch entry: %v", err)), nil
+	if args.Order != nil {
+		filter.Order = *args.Order
 	}
-
-	entryJSON, err := json.MarshalIndent(entry, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal entry: %v", err)), nil
+	if args.Direction != nil {
+		filter.Direction = *args.Direction
 	}
-
-	return mcp.NewToolResultText(string(entryJSON)), nil
+	return filter, nil
 }
 
-func (s *MinifluxServer) UpdateEntryStatus(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	args := request.Params.Arguments
-	if args == nil {
-		return mcp.NewToolResultError("entry_id and status are required"), nil
+// entryFilter builds the Miniflux filter selecting the entries matched by
+// the entry filter arguments, resolving the feed and category references.
+func (s *MinifluxServer) entryFilter(args entryFilterArgs) (*client.Filter, error) {
+	filter := &client.Filter{Statuses: args.Statuses}
+	if args.feedReference.given() {
+		feedID, err := s.resolveFeed(args.feedReference)
+		if err != nil {
+			return nil, err
+		}
+		filter.FeedID = feedID
 	}
-
-	argsMap, ok := args.(map[string]interface{})
-	if !ok {
-		return mcp.NewToolResultError("Invalid arguments format"), nil
+	if args.categoryReference.given() {
+		categoryID, err := s.resolveCategory(args.categoryReference)
+		if err != nil {
+			return nil, err
+		}
+		filter.CategoryID = categoryID
 	}
-
-	entryIDFloat, ok := argsMap["entry_id"].(float64)
-	if !ok {
-		return mcp.NewToolResultError("entry_id must be a number"), nil
+	if args.PublishedAfter != nil {
+		filter.PublishedAfter = *args.PublishedAfter
 	}
-
-	status, ok := argsMap["status"].(string)
-	if !ok {
-		return mcp.NewToolResultError("status must be a string"), nil
+	if args.PublishedBefore != nil {
+		filter.PublishedBefore = *args.PublishedBefore
 	}
-
-	entryID := int64(entryIDFloat)
-	err := s.client.UpdateEntries([]int64{entryID}, status)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to update entry status: %v", err)), nil
+	if args.ChangedAfter != nil {
+		filter.ChangedAfter = *args.ChangedAfter
 	}
-
-	return mcp.NewToolResultText(fmt.Sprintf("Entry %d status updated to: %s", entryID, status)), nil
-}
-
-func (s *MinifluxServer) CreateFeed(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	args := request.Params.Arguments
-	if args == nil {
-		return mcp.NewToolResultError("feed_url is required"), nil
+	if args.ChangedBefore != nil {
+		filter.ChangedBefore = *args.ChangedBefore
 	}
-
-	argsMap, ok := args.(map[string]interface{})
-	if !ok {
-		return mcp.NewToolResultError("Invalid arguments format"), nil
+	if args.BeforeEntryID != nil {
+		filter.BeforeEntryID = *args.BeforeEntryID
 	}
-
-	feedURL, ok := argsMap["feed_url"].(string)
-	if !ok {
-		return mcp.NewToolResultError("feed_url must be a string"), nil
+	if args.AfterEntryID != nil {
+		filter.AfterEntryID = *args.AfterEntryID
 	}
+	if args.Search != nil {
+		filter.Search = *args.Search
+	}
+	if args.Starred != nil {
+		if *args.Starred {
+			filter.Starred = client.FilterOnlyStarred
+		} else {
+			filter.Starred = client.FilterNotStarred
+		}
+	}
+	if args.GloballyVisible != nil {
+		filter.GloballyVisible = *args.GloballyVisible
+	}
+	return filter, nil
+}
 
-	var categoryID int64 = 1 // Default category
-	if categoryIDFloat, ok := argsMap["category_id"].(float64); ok {
-		categoryID = int64(categoryIDFloat)
+func (s *MinifluxServer) GetEntry(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args getEntryArgs
+	if err := bindArguments(request, &args); err != nil {
+		return argumentErrorResult(err), nil
 	}
 
-	feedRequest := &client.FeedCreationRequest{
-		FeedURL:    feedURL,
-		CategoryID: categoryID,
+	entry, err := s.client.Entry(args.EntryID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to fetch entry: %v", err)), nil
 	}
 
-	if crawler, ok := argsMap["crawler"].(bool); ok {
-		feedRequest.Crawler = crawler
+	return entryResult(entry, s.entryView(args.Format, args.MaxChars, nil, nil)), nil
+}
+
+func (s *MinifluxServer) UpdateEntryStatus(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args updateEntryStatusArgs
+	if err := bindArguments(request, &args); err != nil {
+		return argumentErrorResult(err), nil
 	}
 
-	if userAgent, ok := argsMap["user_agent"].(string); ok {
-		feedRequest.UserAgent = userAgent
+	err := s.client.UpdateEntries([]int64{args.EntryID}, args.Status)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to update entry status: %v", err)), nil
 	}
 
-	if username, ok := argsMap["username"].(string); ok {
-		feedRequest.Username = username
+	return mcp.NewToolResultText(fmt.Sprintf("Entry %d status updated to: %s", args.EntryID, args.Status)), nil
+}
+
+func (s *MinifluxServer) CreateFeed(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args createFeedArgs
+	if err := bindArguments(request, &args); err != nil {
+		return argumentErrorResult(err), nil
+	}
+	// Without a category, Miniflux adds the feed to its default category.
+	var categoryID int64
+	if args.categoryReference.given() {
+		var err error
+		if categoryID, err = s.resolveCategory(args.categoryReference); err != nil {
+			return argumentErrorResult(err), nil
+		}
 	}
 
-	if password, ok := argsMap["password"].(string); ok {
-		feedRequest.Password = password
+	feedRequest := &client.FeedCreationRequest{
+		FeedURL:                     args.FeedURL,
+		CategoryID:                  categoryID,
+		UserAgent:                   args.UserAgent,
+		Cookie:                      args.Cookie,
+		Username:                    args.Username,
+		Password:                    args.Password,
+		Crawler:                     args.Crawler,
+		IgnoreEntryUpdates:          args.IgnoreEntryUpdates,
+		Disabled:                    args.Disabled,
+		NoMediaPlayer:               args.NoMediaPlayer,
+		IgnoreHTTPCache:             args.IgnoreHTTPCache,
+		AllowSelfSignedCertificates: args.AllowSelfSignedCertificates,
+		FetchViaProxy:               args.FetchViaProxy,
+		ScraperRules:                args.ScraperRules,
+		RewriteRules:                args.RewriteRules,
+		UrlRewriteRules:             args.UrlRewriteRules,
+		BlocklistRules:              args.BlocklistRules,
+		KeeplistRules:               args.KeeplistRules,
+		BlockFilterEntryRules:       args.BlockFilterEntryRules,
+		KeepFilterEntryRules:        args.KeepFilterEntryRules,
+		HideGlobally:                args.HideGlobally,
+		DisableHTTP2:                args.DisableHTTP2,
+		ProxyURL:                    args.ProxyURL,
 	}
 
 	createdFeed, err := s.client.CreateFeed(feedRequest)
@@ -286,6 +268,11 @@ func (s *MinifluxServer) CreateFeed(ctx context.Context, request mcp.CallToolReq
 }
 
 func (s *MinifluxServer) GetCategories(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args noArgs
+	if err := bindArguments(request, &args); err != nil {
+		return argumentErrorResult(err), nil
+	}
+
 	categories, err := s.client.Categories()
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to fetch categories: %v", err)), nil
@@ -296,27 +283,20 @@ func (s *MinifluxServer) GetCategories(ctx context.Context, request mcp.CallTool
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal categories: %v", err)), nil
 	}
 
-	return mcp.NewToolResultText(string(categoriesJSON)), nil
+	return mcp.NewToolResultStructured(categoryList{Categories: categories}, string(categoriesJSON)), nil
 }
 
 func (s *MinifluxServer) RefreshFeed(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	args := request.Params.Arguments
-	if args == nil {
-		return mcp.NewToolResultError("feed_id is required"), nil
-	}
-
-	argsMap, ok := args.(map[string]interface{})
-	if !ok {
-		return mcp.NewToolResultError("Invalid arguments format"), nil
+	var args feedArgs
+	if err := bindArguments(request, &args); err != nil {
+		return argumentErrorResult(err), nil
 	}
-
-	feedIDFloat, ok := argsMap["feed_id"].(float64)
-	if !ok {
-		return mcp.NewToolResultError("feed_id must be a number"), nil
+	feedID, err := s.resolveFeed(args.feedReference)
+	if err != nil {
+		return argumentErrorResult(err), nil
 	}
 
-	feedID := int64(feedIDFloat)
-	err := s.client.RefreshFeed(feedID)
+	err = s.client.RefreshFeed(feedID)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to refresh feed: %v", err)), nil
 	}
@@ -326,6 +306,11 @@ func (s *MinifluxServer) RefreshFeed(ctx context.Context, request mcp.CallToolRe
 
 // User Management Methods
 func (s *MinifluxServer) GetUsers(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args noArgs
+	if err := bindArguments(request, &args); err != nil {
+		return argumentErrorResult(err), nil
+	}
+
 	users, err := s.client.Users()
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to fetch users: %v", err)), nil
@@ -336,10 +321,15 @@ func (s *MinifluxServer) GetUsers(ctx context.Context, request mcp.CallToolReque
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal users: %v", err)), nil
 	}
 
-	return mcp.NewToolResultText(string(usersJSON)), nil
+	return mcp.NewToolResultStructured(userList{Users: users}, string(usersJSON)), nil
 }
 
 func (s *MinifluxServer) GetMe(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args noArgs
+	if err := bindArguments(request, &args); err != nil {
+		return argumentErrorResult(err), nil
+	}
+
 	user, err := s.client.Me()
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to fetch current user: %v", err)), nil
@@ -350,27 +340,16 @@ func (s *MinifluxServer) GetMe(ctx context.Context, request mcp.CallToolRequest)
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal user: %v", err)), nil
 	}
 
-	return mcp.NewToolResultText(string(userJSON)), nil
+	return mcp.NewToolResultStructured(user, string(userJSON)), nil
 }
 
 func (s *MinifluxServer) GetUserByID(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	args := request.Params.Arguments
-	if args == nil {
-		return mcp.NewToolResultError("user_id is required"), nil
-	}
-
-	argsMap, ok := args.(map[string]interface{})
-	if !ok {
-		return mcp.NewToolResultError("Invalid arguments format"), nil
-	}
-
-	userIDFloat, ok := argsMap["user_id"].(float64)
-	if !ok {
-		return mcp.NewToolResultError("user_id must be a number"), nil
+	var args userArgs
+	if err := bindArguments(request, &args); err != nil {
+		return argumentErrorResult(err), nil
 	}
 
-	userID := int64(userIDFloat)
-	user, err := s.client.UserByID(userID)
+	user, err := s.client.UserByID(args.UserID)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to fetch user: %v", err)), nil
 	}
@@ -380,26 +359,16 @@ func (s *MinifluxServer) GetUserByID(ctx context.Context, request mcp.CallToolRe
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal user: %v", err)), nil
 	}
 
-	return mcp.NewToolResultText(string(userJSON)), nil
+	return mcp.NewToolResultStructured(user, string(userJSON)), nil
 }
 
 func (s *MinifluxServer) GetUserByUsername(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	args := request.Params.Arguments
-	if args == nil {
-		return mcp.NewToolResultError("username is required"), nil
-	}
-
-	argsMap, ok := args.(map[string]interface{})
-	if !ok {
-		return mcp.NewToolResultError("Invalid arguments format"), nil
-	}
-
-	username, ok := argsMap["username"].(string)
-	if !ok {
-		return mcp.NewToolResultError("username must be a string"), nil
+	var args usernameArgs
+	if err := bindArguments(request, &args); err != nil {
+		return argumentErrorResult(err), nil
 	}
 
-	user, err := s.client.UserByUsername(username)
+	user, err := s.client.UserByUsername(args.Username)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to fetch user: %v", err)), nil
 	}
@@ -409,91 +378,121 @@ func (s *MinifluxServer) GetUserByUsername(ctx context.Context, request mcp.Call
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal user: %v", err)), nil
 	}
 
-	return mcp.NewToolResultText(string(userJSON)), nil
+	return mcp.NewToolResultStructured(user, string(userJSON)), nil
 }
 
 func (s *MinifluxServer) CreateUser(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	args := request.Params.Arguments
-	if args == nil {
-		return mcp.NewToolResultError("username and password are required"), nil
+	var args createUserArgs
+	if err := bindArguments(request, &args); err != nil {
+		return argumentErrorResult(err), nil
 	}
 
-	argsMap, ok := args.(map[string]interface{})
-	if !ok {
-		return mcp.NewToolResultError("Invalid arguments format"), nil
+	user, err := s.client.CreateUser(args.Username, args.Password, args.IsAdmin)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to create user: %v", err)), nil
 	}
 
-	username, ok := argsMap["username"].(string)
-	if !ok {
-		return mcp.NewToolResultError("username must be a string"), nil
+	userJSON, err := json.MarshalIndent(user, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal user: %v", err)), nil
 	}
 
-	password, ok := argsMap["password"].(string)
-	if !ok {
-		return mcp.NewToolResultError("password must be a string"), nil
-	}
+	return mcp.NewToolResultStructured(user, string(userJSON)), nil
+}
 
-	var isAdmin bool
-	if adminVal, ok := argsMap["is_admin"].(bool); ok {
-		isAdmin = adminVal
+func (s *MinifluxServer) UpdateUser(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args updateUserArgs
+	if err := bindArguments(request, &args); err != nil {
+		return argumentErrorResult(err), nil
+	}
+
+	changes := &client.UserModificationRequest{
+		Username:                  args.Username,
+		IsAdmin:                   args.IsAdmin,
+		Theme:                     args.Theme,
+		Language:                  args.Language,
+		Timezone:                  args.Timezone,
+		EntryDirection:            args.EntrySortingDirection,
+		EntryOrder:                args.EntrySortingOrder,
+		EntriesPerPage:            args.EntriesPerPage,
+		KeyboardShortcuts:         args.KeyboardShortcuts,
+		ShowReadingTime:           args.ShowReadingTime,
+		MarkReadOnView:            args.MarkReadOnView,
+		EntrySwipe:                args.EntrySwipe,
+		GestureNav:                args.GestureNav,
+		DisplayMode:               args.DisplayMode,
+		DefaultHomePage:           args.DefaultHomePage,
+		CategoriesSortingOrder:    args.CategoriesSortingOrder,
+		DefaultReadingSpeed:       args.DefaultReadingSpeed,
+		CJKReadingSpeed:           args.CJKReadingSpeed,
+		Stylesheet:                args.Stylesheet,
+		CustomJS:                  args.CustomJS,
+		ExternalFontHosts:         args.ExternalFontHosts,
+		BlockFilterEntryRules:     args.BlockFilterEntryRules,
+		KeepFilterEntryRules:      args.KeepFilterEntryRules,
+		AlwaysOpenExternalLinks:   args.AlwaysOpenExternalLinks,
+		OpenExternalLinksInNewTab: args.OpenExternalLinksNewTab,
+	}
+	if *changes == (client.UserModificationRequest{}) {
+		return mcp.NewToolResultError("at least one field to update is required"), nil
+	}
+
+	before, err := s.userBeforeUpdate(args.UserID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to fetch user: %v", err)), nil
 	}
-
-	user, err := s.client.CreateUser(username, password, isAdmin)
+	after, err := s.client.UpdateUser(args.UserID, changes)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to create user: %v", err)), nil
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to update user: %v", err)), nil
 	}
 
-	userJSON, err := json.MarshalIndent(user, "", "  ")
+	update, err := newUserUpdate(before, after)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal user: %v", err)), nil
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to compare user settings: %v", err)), nil
 	}
-
-	return mcp.NewToolResultText(string(userJSON)), nil
+	return mcp.NewToolResultStructured(update, update.diff()), nil
 }
 
-func (s *MinifluxServer) DeleteUser(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	args := request.Params.Arguments
-	if args == nil {
-		return mcp.NewToolResultError("user_id is required"), nil
+// userBeforeUpdate fetches the user update_user is about to change. Only
+// admins may fetch users by ID, but anyone may update their own account, so
+// on a forbidden lookup the current user is used when it is the one updated.
+func (s *MinifluxServer) userBeforeUpdate(userID int64) (*client.User, error) {
+	user, err := s.client.UserByID(userID)
+	if !errors.Is(err, client.ErrForbidden) {
+		return user, err
 	}
-
-	argsMap, ok := args.(map[string]interface{})
-	if !ok {
-		return mcp.NewToolResultError("Invalid arguments format"), nil
+	me, meErr := s.client.Me()
+	if meErr != nil || me.ID != userID {
+		return nil, err
 	}
+	return me, nil
+}
 
-	userIDFloat, ok := argsMap["user_id"].(float64)
-	if !ok {
-		return mcp.NewToolResultError("user_id must be a number"), nil
+func (s *MinifluxServer) DeleteUser(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args userArgs
+	if err := bindArguments(request, &args); err != nil {
+		return argumentErrorResult(err), nil
 	}
 
-	userID := int64(userIDFloat)
-	err := s.client.DeleteUser(userID)
+	err := s.client.DeleteUser(args.UserID)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to delete user: %v", err)), nil
 	}
 
-	return mcp.NewToolResultText(fmt.Sprintf("User %d deleted successfully", userID)), nil
+	return mcp.NewToolResultText(fmt.Sprintf("User %d deleted successfully", args.UserID)), nil
 }
 
 // Category Management Methods
 func (s *MinifluxServer) CreateCategory(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	args := request.Params.Arguments
-	if args == nil {
-		return mcp.NewToolResultError("title is required"), nil
-	}
-
-	argsMap, ok := args.(map[string]interface{})
-	if !ok {
-		return mcp.NewToolResultError("Invalid arguments format"), nil
-	}
-
-	title, ok := argsMap["title"].(string)
-	if !ok {
-		return mcp.NewToolResultError("title must be a string"), nil
+	var args createCategoryArgs
+	if err := bindArguments(request, &args); err != nil {
+		return argumentErrorResult(err), nil
 	}
 
-	category, err := s.client.CreateCategory(title)
+	category, err := s.client.CreateCategoryWithOptions(&client.CategoryCreationRequest{
+		Title:        args.Title,
+		HideGlobally: args.HideGlobally,
+	})
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to create category: %v", err)), nil
 	}
@@ -503,32 +502,28 @@ func (s *MinifluxServer) CreateCategory(ctx context.Context, request mcp.CallToo
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal category: %v", err)), nil
 	}
 
-	return mcp.NewToolResultText(string(categoryJSON)), nil
+	return mcp.NewToolResultStructured(category, string(categoryJSON)), nil
 }
 
 func (s *MinifluxServer) UpdateCategory(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	args := request.Params.Arguments
-	if args == nil {
-		return mcp.NewToolResultError("category_id and title are required"), nil
+	var args updateCategoryArgs
+	if err := bindArguments(request, &args); err != nil {
+		return argumentErrorResult(err), nil
 	}
-
-	argsMap, ok := args.(map[string]interface{})
-	if !ok {
-		return mcp.NewToolResultError("Invalid arguments format"), nil
+	categoryID, err := s.resolveCategory(args.categoryReference)
+	if err != nil {
+		return argumentErrorResult(err), nil
 	}
 
-	categoryIDFloat, ok := argsMap["category_id"].(float64)
-	if !ok {
-		return mcp.NewToolResultError("category_id must be a number"), nil
+	changes := &client.CategoryModificationRequest{
+		Title:        args.Title,
+		HideGlobally: args.HideGlobally,
 	}
-
-	title, ok := argsMap["title"].(string)
-	if !ok {
-		return mcp.NewToolResultError("title must be a string"), nil
+	if *changes == (client.CategoryModificationRequest{}) {
+		return mcp.NewToolResultError("at least one of title or hide_globally is required"), nil
 	}
 
-	categoryID := int64(categoryIDFloat)
-	category, err := s.client.UpdateCategory(categoryID, title)
+	category, err := s.client.UpdateCategoryWithOptions(categoryID, changes)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to update category: %v", err)), nil
 	}
@@ -538,27 +533,20 @@ func (s *MinifluxServer) UpdateCategory(ctx context.Context, request mcp.CallToo
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal category: %v", err)), nil
 	}
 
-	return mcp.NewToolResultText(string(categoryJSON)), nil
+	return mcp.NewToolResultStructured(category, string(categoryJSON)), nil
 }
 
 func (s *MinifluxServer) DeleteCategory(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	args := request.Params.Arguments
-	if args == nil {
-		return mcp.NewToolResultError("category_id is required"), nil
+	var args categoryArgs
+	if err := bindArguments(request, &args); err != nil {
+		return argumentErrorResult(err), nil
 	}
-
-	argsMap, ok := args.(map[string]interface{})
-	if !ok {
-		return mcp.NewToolResultError("Invalid arguments format"), nil
-	}
-
-	categoryIDFloat, ok := argsMap["category_id"].(float64)
-	if !ok {
-		return mcp.NewToolResultError("category_id must be a number"), nil
+	categoryID, err := s.resolveCategory(args.categoryReference)
+	if err != nil {
+		return argumentErrorResult(err), nil
 	}
 
-	categoryID := int64(categoryIDFloat)
-	err := s.client.DeleteCategory(categoryID)
+	err = s.client.DeleteCategory(categoryID)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to delete category: %v", err)), nil
 	}
@@ -567,97 +555,74 @@ func (s *MinifluxServer) DeleteCategory(ctx context.Context, request mcp.CallToo
 }
 
 func (s *MinifluxServer) GetCategoryFeeds(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	args := request.Params.Arguments
-	if args == nil {
-		return mcp.NewToolResultError("category_id is required"), nil
+	var args categoryFeedsArgs
+	if err := bindArguments(request, &args); err != nil {
+		return argumentErrorResult(err), nil
 	}
-
-	argsMap, ok := args.(map[string]interface{})
-	if !ok {
-		return mcp.NewToolResultError("Invalid arguments format"), nil
+	categoryID, err := s.resolveCategory(args.categoryReference)
+	if err != nil {
+		return argumentErrorResult(err), nil
 	}
-
-	categoryIDFloat, ok := argsMap["category_id"].(float64)
-	if !ok {
-		return mcp.NewToolResultError("category_id must be a number"), nil
+	fields, err := parseFieldSelection(args.Fields, reflect.TypeFor[client.Feed]())
+	if err != nil {
+		return argumentErrorResult(err), nil
 	}
 
-	categoryID := int64(categoryIDFloat)
 	feeds, err := s.client.CategoryFeeds(categoryID)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to fetch category feeds: %v", err)), nil
 	}
 
-	feedsJSON, err := json.MarshalIndent(feeds, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal feeds: %v", err)), nil
-	}
-
-	return mcp.NewToolResultText(string(feedsJSON)), nil
+	return feedsResult(feeds, fields), nil
 }
 
 func (s *MinifluxServer) GetCategoryEntries(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	args := request.Params.Arguments
-	if args == nil {
-		return mcp.NewToolResultError("category_id is required"), nil
+	var args categoryEntriesArgs
+	if err := bindArguments(request, &args); err != nil {
+		return argumentErrorResult(err), nil
 	}
-
-	argsMap, ok := args.(map[string]interface{})
-	if !ok {
-		return mcp.NewToolResultError("Invalid arguments format"), nil
+	categoryID, err := s.resolveCategory(args.categoryReference)
+	if err != nil {
+		return argumentErrorResult(err), nil
 	}
-
-	categoryIDFloat, ok := argsMap["category_id"].(float64)
-	if !ok {
-		return mcp.NewToolResultError("category_id must be a number"), nil
+	fields, err := entryFieldSelection(args.Fields, args.Format)
+	if err != nil {
+		return argumentErrorResult(err), nil
 	}
 
-	categoryID := int64(categoryIDFloat)
-
-	// Parse optional filter parameters
-	var filter *client.Filter
-	if statusStr, ok := argsMap["status"].(string); ok {
-		filter = &client.Filter{Status: statusStr}
-	}
-	if limitFloat, ok := argsMap["limit"].(float64); ok {
-		if filter == nil {
-			filter = &client.Filter{}
+	filter := scopedEntriesFilter(args.Status, args.Limit, args.Offset, args.Order, args.Direction)
+	if args.Cursor != nil {
+		if filter, err = entryFilterFromCursor(request, *args.Cursor, args.Limit, "get_category_entries", categoryID); err != nil {
+			return argumentErrorResult(err), nil
 		}
-		limit := int(limitFloat)
-		filter.Limit = limit
 	}
 
-	entries, err := s.client.CategoryEntries(categoryID, filter)
+	listEntries := func(filter *client.Filter) (*client.EntryResultSet, error) {
+		return s.client.CategoryEntries(categoryID, filter)
+	}
+	entries, err := listEntries(filter)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to fetch category entries: %v", err)), nil
 	}
-
-	entriesJSON, err := json.MarshalIndent(entries, "", "  ")
+	pagination, err := paginateEntries("get_category_entries", categoryID, filter, entries, listEntries)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal entries: %v", err)), nil
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to fetch category entries: %v", err)), nil
 	}
 
-	return mcp.NewToolResultText(string(entriesJSON)), nil
+	return entriesResult(entries, s.entryView(args.Format, args.MaxChars, fields, &pagination)), nil
 }
 
 func (s *MinifluxServer) MarkCategoryAsRead(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	args := request.Params.Arguments
-	if args == nil {
-		return mcp.NewToolResultError("category_id is required"), nil
-	}
-
-	argsMap, ok := args.(map[string]interface{})
-	if !ok {
-		return mcp.NewToolResultError("Invalid arguments format"), nil
+	var args categoryArgs
+	if err := bindArguments(request, &args); err != nil {
+		return argumentErrorResult(err), nil
 	}
-
-	categoryIDFloat, ok := argsMap["category_id"].(float64)
-	if !ok {
-		return mcp.NewToolResultError("category_id must be a number"), nil
+	categoryID, err := s.resolveCategory(args.categoryReference)
+	if err != nil {
+		return argumentErrorResult(err), nil
 	}
 
-	categoryID := int64(categoryIDFloat)
-	err := s.client.MarkCategoryAsRead(categoryID)
+	err = s.client.MarkCategoryAsRead(categoryID)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to mark category as read: %v", err)), nil
 	}
@@ -666,23 +631,16 @@ func (s *MinifluxServer) MarkCategoryAsRead(ctx context.Context, request mcp.Cal
 }
 
 func (s *MinifluxServer) RefreshCategory(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	args := request.Params.Arguments
-	if args == nil {
-		return mcp.NewToolResultError("category_id is required"), nil
+	var args categoryArgs
+	if err := bindArguments(request, &args); err != nil {
+		return argumentErrorResult(err), nil
 	}
-
-	argsMap, ok := args.(map[string]interface{})
-	if !ok {
-		return mcp.NewToolResultError("Invalid arguments format"), nil
-	}
-
-	categoryIDFloat, ok := argsMap["category_id"].(float64)
-	if !ok {
-		return mcp.NewToolResultError("category_id must be a number"), nil
+	categoryID, err := s.resolveCategory(args.categoryReference)
+	if err != nil {
+		return argumentErrorResult(err), nil
 	}
 
-	categoryID := int64(categoryIDFloat)
-	err := s.client.RefreshCategory(categoryID)
+	err = s.client.RefreshCategory(categoryID)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to refresh category: %v", err)), nil
 	}
@@ -690,20 +648,65 @@ func (s *MinifluxServer) RefreshCategory(ctx context.Context, request mcp.CallTo
 	return mcp.NewToolResultText(fmt.Sprintf("Category %d refreshed successfully", categoryID)), nil
 }
 
+// newMCPServer creates the MCP server and registers the Miniflux tools,
+// resources and prompts on it. Resource subscriptions are offered when the
+// Miniflux server tracks them.
+func newMCPServer(minifluxServer *MinifluxServer) *server.MCPServer {
+	completions := newCompletionProvider(minifluxServer)
+	options := []server.ServerOption{
+		server.WithLogging(),
+		server.WithToolHandlerMiddleware(minifluxServer.guardDisabledTools),
+		server.WithCompletions(),
+		server.WithPromptCompletionProvider(completions),
+		server.WithResourceCompletionProvider(completions),
+	}
+	if minifluxServer.subscriptions != nil {
+		options = append(options,
+			server.WithResourceCapabilities(true, false),
+			server.WithHooks(minifluxServer.subscriptions.hooks()),
+		)
+	}
+	mcpServer := server.NewMCPServer("miniflux-mcp", Version, options...)
+	minifluxServer.RegisterAllTools(mcpServer)
+	minifluxServer.RegisterAllResources(mcpServer)
+	minifluxServer.RegisterAllPrompts(mcpServer)
+	return mcpServer
+}
+
 func main() {
 	transport, err := loadTransportConfig()
 	if err != nil {
 		log.Fatalf("Invalid transport configuration: %v", err)
 	}
+	tools, err := loadToolConfig()
+	if err != nil {
+		log.Fatalf("Invalid tool configuration: %v", err)
+	}
+	flag.BoolVar(&tools.ReadOnly, "read-only", tools.ReadOnly, "expose only tools that do not modify Miniflux (overrides MCP_READ_ONLY)")
+	flag.Func("tools", "comma-separated tool groups, names or patterns to expose (overrides MCP_TOOLS)", func(value string) error {
+		tools.Enabled = splitToolSelection(value)
+		return nil
+	})
+	flag.Func("deny-tools", "comma-separated tool groups, names or patterns to hide (overrides MCP_TOOLS_DENY)", func(value string) error {
+		tools.Disabled = splitToolSelection(value)
+		return nil
+	})
+	flag.IntVar(&tools.MaxChars, "max-chars", tools.MaxChars, "default response budget of entry tools in characters, 0 for unlimited (overrides MCP_MAX_CHARS)")
+	flag.Parse()
+	if err := tools.validate(); err != nil {
+		log.Fatalf("Invalid tool configuration: %v", err)
+	}
 	log.Printf("Starting miniflux-mcp version=%s revision=%s build_date=%s", Version, Revision, BuildDate)
 
 	minifluxServer := NewMinifluxServer()
-	mcpServer := server.NewMCPServer(
-		"miniflux-mcp",
-		Version,
-		server.WithLogging(),
-	)
-	minifluxServer.RegisterAllTools(mcpServer)
+	minifluxServer.tools = tools
+	if transport.deliversNotifications() {
+		minifluxServer.subscriptions = newResourceSubscriptions()
+	}
+	mcpServer := newMCPServer(minifluxServer)
+	if minifluxServer.subscriptions != nil {
+		go newResourcePoller(minifluxServer, mcpServer).run(context.Background(), transport.PollInterval)
+	}
 
 	if err := serveMCP(mcpServer, transport); err != nil {
 		log.Fatalf("Server failed: %v", err)
@@ -0,0 +1,297 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// htmlToMarkdown converts entry HTML to Markdown. Links, emphasis, headings,
+// lists, block quotes and code blocks are kept; images are replaced by their
+// alt text and scripts, styles and other markup are dropped.
+func htmlToMarkdown(content string) string {
+	return renderHTML(content, false)
+}
+
+// htmlToText converts entry HTML to plain text, keeping paragraphs and list
+// structure but no markup.
+func htmlToText(content string) string {
+	return renderHTML(content, true)
+}
+
+func renderHTML(content string, plain bool) string {
+	context := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+	nodes, err := html.ParseFragment(strings.NewReader(content), context)
+	if err != nil {
+		return strings.TrimSpace(content)
+	}
+
+	renderer := htmlRenderer{plain: plain}
+	var writer blockWriter
+	for _, node := range nodes {
+		renderer.render(&writer, node)
+	}
+	return writer.join("\n\n")
+}
+
+type htmlRenderer struct {
+	plain bool
+}
+
+func (r htmlRenderer) render(w *blockWriter, node *html.Node) {
+	switch node.Type {
+	case html.TextNode:
+		w.text(collapseWhitespace(node.Data))
+		return
+	case html.ElementNode:
+	default:
+		r.renderChildren(w, node)
+		return
+	}
+
+	switch node.DataAtom {
+	case atom.Script, atom.Style, atom.Head, atom.Template, atom.Noscript, atom.Iframe, atom.Svg:
+	case atom.Br:
+		w.lineBreak()
+	case atom.Hr:
+		if !r.plain {
+			w.block("---")
+		}
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		heading := r.inline(node)
+		if !r.plain && heading != "" {
+			level, _ := strconv.Atoi(node.Data[1:])
+			heading = strings.Repeat("#", level) + " " + heading
+		}
+		w.block(heading)
+	case atom.Pre:
+		code := strings.Trim(textContent(node), "\n")
+		if !r.plain {
+			code = "```\n" + code + "\n```"
+		}
+		w.block(code)
+	case atom.Blockquote:
+		quote := r.fragment(node, "\n\n")
+		if !r.plain && quote != "" {
+			quote = prefixLines(quote, "> ", "> ")
+		}
+		w.block(quote)
+	case atom.Ul, atom.Ol:
+		w.block(r.list(node))
+	case atom.Table:
+		w.block(r.table(node))
+	case atom.P, atom.Div, atom.Section, atom.Article, atom.Header, atom.Footer, atom.Main, atom.Aside,
+		atom.Nav, atom.Figure, atom.Figcaption, atom.Details, atom.Summary, atom.Dl, atom.Dt, atom.Dd, atom.Li:
+		w.flush()
+		r.renderChildren(w, node)
+		w.flush()
+	case atom.Img:
+		w.text(strings.TrimSpace(attribute(node, "alt")))
+	case atom.A:
+		text := r.inline(node)
+		href := strings.TrimSpace(attribute(node, "href"))
+		if !r.plain && text != "" && isLinkTarget(href) {
+			text = "[" + text + "](" + href + ")"
+		}
+		w.text(text)
+	case atom.Strong, atom.B:
+		w.text(r.wrap(r.inline(node), "**"))
+	case atom.Em, atom.I:
+		w.text(r.wrap(r.inline(node), "*"))
+	case atom.Del, atom.S, atom.Strike:
+		w.text(r.wrap(r.inline(node), "~~"))
+	case atom.Code:
+		w.text(r.wrap(collapseWhitespace(textContent(node)), "`"))
+	default:
+		r.renderChildren(w, node)
+	}
+}
+
+func (r htmlRenderer) renderChildren(w *blockWriter, node *html.Node) {
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		r.render(w, child)
+	}
+}
+
+// fragment renders the children of node as a standalone document.
+func (r htmlRenderer) fragment(node *html.Node, separator string) string {
+	var w blockWriter
+	r.renderChildren(&w, node)
+	return w.join(separator)
+}
+
+// inline renders the children of node on a single line.
+func (r htmlRenderer) inline(node *html.Node) string {
+	return strings.Join(strings.Fields(r.fragment(node, " ")), " ")
+}
+
+func (r htmlRenderer) wrap(text, marker string) string {
+	if r.plain || text == "" {
+		return text
+	}
+	return marker + text + marker
+}
+
+func (r htmlRenderer) list(node *html.Node) string {
+	var items []string
+	number := 1
+	if start, err := strconv.Atoi(attribute(node, "start")); err == nil {
+		number = start
+	}
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type != html.ElementNode || child.DataAtom != atom.Li {
+			continue
+		}
+		marker := "- "
+		if node.DataAtom == atom.Ol {
+			marker = strconv.Itoa(number) + ". "
+			number++
+		}
+		item := r.fragment(child, "\n")
+		items = append(items, prefixLines(item, marker, strings.Repeat(" ", len(marker))))
+	}
+	return strings.Join(items, "\n")
+}
+
+// table renders each row on its own line with cells separated by pipes.
+func (r htmlRenderer) table(node *html.Node) string {
+	var rows []string
+	var visit func(*html.Node)
+	visit = func(n *html.Node) {
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			if child.Type != html.ElementNode {
+				continue
+			}
+			if child.DataAtom != atom.Tr {
+				visit(child)
+				continue
+			}
+			var cells []string
+			for cell := child.FirstChild; cell != nil; cell = cell.NextSibling {
+				if cell.Type == html.ElementNode && (cell.DataAtom == atom.Td || cell.DataAtom == atom.Th) {
+					cells = append(cells, r.inline(cell))
+				}
+			}
+			if len(cells) > 0 {
+				rows = append(rows, strings.Join(cells, " | "))
+			}
+		}
+	}
+	visit(node)
+	return strings.Join(rows, "\n")
+}
+
+// blockWriter accumulates rendered blocks, joining consecutive inline text
+// into the current block.
+type blockWriter struct {
+	blocks []string
+	line   strings.Builder
+}
+
+func (w *blockWriter) text(text string) {
+	if text == "" {
+		return
+	}
+	current := w.line.String()
+	if strings.HasSuffix(current, " ") || strings.HasSuffix(current, "\n") {
+		text = strings.TrimLeft(text, " ")
+	}
+	w.line.WriteString(text)
+}
+
+func (w *blockWriter) lineBreak() {
+	w.line.WriteString("\n")
+}
+
+func (w *blockWriter) flush() {
+	var lines []string
+	for _, line := range strings.Split(w.line.String(), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	w.line.Reset()
+	if len(lines) > 0 {
+		w.blocks = append(w.blocks, strings.Join(lines, "\n"))
+	}
+}
+
+func (w *blockWriter) block(text string) {
+	w.flush()
+	if text != "" {
+		w.blocks = append(w.blocks, text)
+	}
+}
+
+func (w *blockWriter) join(separator string) string {
+	w.flush()
+	return strings.Join(w.blocks, separator)
+}
+
+func collapseWhitespace(text string) string {
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		if text != "" {
+			return " "
+		}
+		return ""
+	}
+	collapsed := strings.Join(fields, " ")
+	if strings.TrimLeft(text[:1], " \t\r\n\f") == "" {
+		collapsed = " " + collapsed
+	}
+	if strings.TrimRight(text[len(text)-1:], " \t\r\n\f") == "" {
+		collapsed += " "
+	}
+	return collapsed
+}
+
+func textContent(node *html.Node) string {
+	var builder strings.Builder
+	var visit func(*html.Node)
+	visit = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			builder.WriteString(n.Data)
+		}
+		if n.Type == html.ElementNode && n.DataAtom == atom.Br {
+			builder.WriteString("\n")
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			visit(child)
+		}
+	}
+	visit(node)
+	return builder.String()
+}
+
+func attribute(node *html.Node, name string) string {
+	for _, attr := range node.Attr {
+		if attr.Namespace == "" && attr.Key == name {
+			return attr.Val
+		}
+	}
+	return ""
+}
+
+func isLinkTarget(href string) bool {
+	lower := strings.ToLower(href)
+	return href != "" && !strings.HasPrefix(href, "#") && !strings.HasPrefix(lower, "javascript:")
+}
+
+func prefixLines(text, first, rest string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		prefix := rest
+		if i == 0 {
+			prefix = first
+		}
+		if line == "" {
+			lines[i] = strings.TrimRight(prefix, " ")
+		} else {
+			lines[i] = prefix + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"miniflux.app/v2/client"
+)
+
+const sampleEntryHTML = `<h2>Release <em>notes</em></h2>
+<p>Read the <a href="https://example.org/post">announcement</a>, it is <strong>big</strong>.<br>Run <code>go test</code>.</p>
+<ul><li>One</li><li>Two<ul><li>Nested</li></ul></li></ul>
+<ol start="3"><li>Three</li><li>Four</li></ol>
+<blockquote><p>First</p><p>Second</p></blockquote>
+<pre><code>if ok {
+    return
+}</code></pre>
+<p><img src="cat.png" alt="A cat"> <a href="javascript:alert(1)">click</a></p>
+<script>alert("hidden")</script>`
+
+func TestHTMLToMarkdown(t *testing.T) {
+	want := strings.Join([]string{
+		"## Release *notes*",
+		"Read the [announcement](https://example.org/post), it is **big**.\nRun `go test`.",
+		"- One\n- Two\n  - Nested",
+		"3. Three\n4. Four",
+		"> First\n>\n> Second",
+		"```\nif ok {\n    return\n}\n```",
+		"A cat click",
+	}, "\n\n")
+	if got := htmlToMarkdown(sampleEntryHTML); got != want {
+		t.Errorf("htmlToMarkdown =\n%s\n\nwant\n%s", got, want)
+	}
+}
+
+func TestHTMLToText(t *testing.T) {
+	want := strings.Join([]string{
+		"Release notes",
+		"Read the announcement, it is big.\nRun go test.",
+		"- One\n- Two\n  - Nested",
+		"3. Three\n4. Four",
+		"First\n\nSecond",
+		"if ok {\n    return\n}",
+		"A cat click",
+	}, "\n\n")
+	if got := htmlToText(sampleEntryHTML); got != want {
+		t.Errorf("htmlToText =\n%s\n\nwant\n%s", got, want)
+	}
+}
+
+func TestEntryFormats(t *testing.T) {
+	published := time.Date(2024, 5, 6, 7, 8, 0, 0, time.UTC)
+	feed := &client.Feed{ID: 42, Title: "Example | News"}
+	entry := &client.Entry{
+		ID:      9,
+		Title:   "Hello",
+		URL:     "https://example.org/hello",
+		Status:  "unread",
+		Starred: true,
+		Date:    published,
+		Content: "<p>Hi <b>there</b></p>",
+		Feed:    feed,
+	}
+
+	markdown := renderEntry(entry, false)
+	for _, want := range []string{"# Hello", "- **Feed:** Example | News", "- **Published:** 2024-05-06 07:08", "- **Status:** unread, starred", "Hi **there**"} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("markdown entry does not contain %q:\n%s", want, markdown)
+		}
+	}
+	if text := renderEntry(entry, true); !strings.Contains(text, "Feed: Example | News") || !strings.Contains(text, "Hi there") {
+		t.Errorf("text entry =\n%s", text)
+	}
+
+	entries := &client.EntryResultSet{Total: 3, Entries: client.Entries{entry}}
+	table := renderEntryTable(entries)
+	if !strings.HasPrefix(table, "Showing 1 of 3 entries\n") {
+		t.Errorf("table summary =\n%s", table)
+	}
+	if !strings.Contains(table, "| 9 | Hello | Example \\| News | 2024-05-06 07:08 | unread, starred |") {
+		t.Errorf("table row =\n%s", table)
+	}
+	if lines := renderEntryLines(entries); !strings.Contains(lines, "9\tHello\tExample | News\t2024-05-06 07:08\tunread, starred\n") {
+		t.Errorf("entry lines =\n%s", lines)
+	}
+}
+
+func TestGetEntryMarkdownFormat(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": 9, "title": "Hello", "status": "read", "content": "<p>Hi <a href=\"https://example.org\">there</a></p>"}`))
+	}))
+	defer apiServer.Close()
+
+	minifluxServer := &MinifluxServer{client: client.NewClient(apiServer.URL, "test-api-key")}
+	request := mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Arguments: map[string]interface{}{"entry_id": float64(9), "format": "markdown"},
+		},
+	}
+
+	result, err := minifluxServer.GetEntry(context.Background(), request)
+	if err != nil {
+		t.Fatalf("GetEntry returned error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("GetEntry returned tool error: %#v", result.Content)
+	}
+	text, ok := mcp.AsTextContent(result.Content[0])
+	if !ok || !strings.Contains(text.Text, "Hi [there](https://example.org)") || strings.Contains(text.Text, "<p>") {
+		t.Errorf("text content = %#v, want Markdown", result.Content[0])
+	}
+	if structured, ok := result.StructuredContent.(*entryDocument); !ok || structured.Content == "" {
+		t.Errorf("structured content = %#v, want the full entry", result.StructuredContent)
+	}
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/url"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// opmlDocument is the subset of OPML read to preview an import.
+type opmlDocument struct {
+	XMLName  xml.Name      `xml:"opml"`
+	Outlines []opmlOutline `xml:"body>outline"`
+}
+
+type opmlOutline struct {
+	Text     string        `xml:"text,attr"`
+	Title    string        `xml:"title,attr"`
+	FeedURL  string        `xml:"xmlUrl,attr"`
+	SiteURL  string        `xml:"htmlUrl,attr"`
+	Outlines []opmlOutline `xml:"outline"`
+}
+
+func (o opmlOutline) name() string {
+	if o.Title != "" {
+		return o.Title
+	}
+	return o.Text
+}
+
+// opmlImportReport is the result of import_opml. Feeds without a category are
+// imported into the default category of the user.
+type opmlImportReport struct {
+	DryRun          bool       `json:"dry_run"`
+	Imported        bool       `json:"imported"`
+	NewFeeds        []opmlFeed `json:"new_feeds"`
+	SubscribedFeeds []opmlFeed `json:"subscribed_feeds"`
+	NewCategories   []string   `json:"new_categories"`
+}
+
+// opmlFeed is a feed of an OPML document. FeedID is set when the feed is
+// already subscribed.
+type opmlFeed struct {
+	Title    string `json:"title"`
+	FeedURL  string `json:"feed_url"`
+	SiteURL  string `json:"site_url,omitempty"`
+	Category string `json:"category,omitempty"`
+	FeedID   int64  `json:"feed_id,omitempty"`
+}
+
+func (s *MinifluxServer) ImportOPML(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args importOPMLArgs
+	if err := bindArguments(request, &args); err != nil {
+		return argumentErrorResult(err), nil
+	}
+
+	data := []byte(args.OPML)
+	feeds, err := parseOPML(data)
+	if err != nil {
+		return argumentErrorResult(err), nil
+	}
+	report, err := s.previewOPMLImport(feeds)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to preview import: %v", err)), nil
+	}
+
+	report.DryRun = args.DryRun
+	if !args.DryRun {
+		if err := s.client.Import(io.NopCloser(bytes.NewReader(data))); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to import OPML: %v", err)), nil
+		}
+		report.Imported = true
+	}
+
+	reportJSON, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal import report: %v", err)), nil
+	}
+	return mcp.NewToolResultStructured(report, string(reportJSON)), nil
+}
+
+// parseOPML validates an OPML document and returns its feeds, each with the
+// title of the outline it is nested in as category.
+func parseOPML(data []byte) ([]opmlFeed, error) {
+	invalid := func(format string, a ...any) error {
+		return &argumentError{Argument: "opml", Reason: reasonInvalidOPML, Message: fmt.Sprintf(format, a...)}
+	}
+
+	var document opmlDocument
+	if err := xml.Unmarshal(data, &document); err != nil {
+		return nil, invalid("invalid OPML document: %v", err)
+	}
+
+	var feeds []opmlFeed
+	seen := map[string]bool{}
+	var walk func(outlines []opmlOutline, category string) error
+	walk = func(outlines []opmlOutline, category string) error {
+		for _, outline := range outlines {
+			if outline.FeedURL == "" {
+				if err := walk(outline.Outlines, outline.name()); err != nil {
+					return err
+				}
+				continue
+			}
+			feedURL, err := url.Parse(outline.FeedURL)
+			if err != nil || (feedURL.Scheme != "http" && feedURL.Scheme != "https") || feedURL.Host == "" {
+				return invalid("outline %q has an invalid feed URL %q", outline.name(), outline.FeedURL)
+			}
+			if !seen[outline.FeedURL] {
+				seen[outline.FeedURL] = true
+				feeds = append(feeds, opmlFeed{Title: outline.name(), FeedURL: outline.FeedURL, SiteURL: outline.SiteURL, Category: category})
+			}
+		}
+		return nil
+	}
+	if err := walk(document.Outlines, ""); err != nil {
+		return nil, err
+	}
+	if len(feeds) == 0 {
+		return nil, invalid("the OPML document contains no feeds")
+	}
+	return feeds, nil
+}
+
+// previewOPMLImport splits the feeds of an OPML document into the ones
+// already subscribed and the new ones, and lists the categories the import
+// would create. Feed URLs and category titles are compared exactly, as
+// Miniflux does when importing.
+func (s *MinifluxServer) previewOPMLImport(feeds []opmlFeed) (*opmlImportReport, error) {
+	subscribed, err := s.client.Feeds()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch feeds: %w", err)
+	}
+	categories, err := s.client.Categories()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch categories: %w", err)
+	}
+
+	feedIDs := make(map[string]int64, len(subscribed))
+	for _, feed := range subscribed {
+		feedIDs[feed.FeedURL] = feed.ID
+	}
+	existingCategories := make(map[string]bool, len(categories))
+	for _, category := range categories {
+		existingCategories[category.Title] = true
+	}
+
+	report := &opmlImportReport{NewFeeds: []opmlFeed{}, SubscribedFeeds: []opmlFeed{}, NewCategories: []string{}}
+	for _, feed := range feeds {
+		if feedID, ok := feedIDs[feed.FeedURL]; ok {
+			feed.FeedID = feedID
+			report.SubscribedFeeds = append(report.SubscribedFeeds, feed)
+			continue
+		}
+		report.NewFeeds = append(report.NewFeeds, feed)
+		if feed.Category != "" && !existingCategories[feed.Category] {
+			existingCategories[feed.Category] = true
+			report.NewCategories = append(report.NewCategories, feed.Category)
+		}
+	}
+	return report, nil
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"miniflux.app/v2/client"
+)
+
+const testOPML = `<?xml version="1.0" encoding="UTF-8"?>
+<opml version="2.0">
+  <head><title>Subscriptions</title></head>
+  <body>
+    <outline text="News">
+      <outline title="Hacker News" text="HN" type="rss" xmlUrl="https://news.ycombinator.com/rss" htmlUrl="https://news.ycombinator.com"/>
+      <outline text="Lobsters" type="rss" xmlUrl="https://lobste.rs/rss"/>
+    </outline>
+    <outline text="Science">
+      <outline text="Quanta" type="rss" xmlUrl="https://www.quantamagazine.org/feed/"/>
+    </outline>
+    <outline text="Go Blog" type="rss" xmlUrl="https://go.dev/blog/feed.atom"/>
+    <outline text="Go Blog again" type="rss" xmlUrl="https://go.dev/blog/feed.atom"/>
+  </body>
+</opml>`
+
+func callImportOPML(t *testing.T, minifluxServer *MinifluxServer, arguments map[string]any) *mcp.CallToolResult {
+	t.Helper()
+	result, err := minifluxServer.ImportOPML(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: arguments}})
+	if err != nil {
+		t.Fatalf("ImportOPML returned error: %v", err)
+	}
+	return result
+}
+
+func TestImportOPML(t *testing.T) {
+	var imported []string
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response any
+		switch r.URL.Path {
+		case "/v1/feeds":
+			response = client.Feeds{{ID: 12, Title: "Hacker News", FeedURL: "https://news.ycombinator.com/rss"}, {ID: 13, Title: "Lobsters", FeedURL: "https://lobste.rs/rss/"}}
+		case "/v1/categories":
+			response = client.Categories{{ID: 1, Title: "All"}, {ID: 7, Title: "News"}, {ID: 8, Title: "science"}}
+		case "/v1/import":
+			body, _ := io.ReadAll(r.Body)
+			imported = append(imported, string(body))
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"message":"Feeds imported successfully"}`))
+			return
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	defer apiServer.Close()
+	minifluxServer := &MinifluxServer{client: client.NewClient(apiServer.URL, "test-api-key")}
+
+	result := callImportOPML(t, minifluxServer, map[string]any{"opml": testOPML})
+	if result.IsError {
+		t.Fatalf("dry run failed: %#v", result.Content)
+	}
+	report := result.StructuredContent.(*opmlImportReport)
+	if report.Imported || len(imported) != 0 {
+		t.Error("a dry run imported the document")
+	}
+	if len(report.SubscribedFeeds) != 1 || report.SubscribedFeeds[0].FeedID != 12 || report.SubscribedFeeds[0].Title != "Hacker News" {
+		t.Errorf("subscribed feeds = %+v, want Hacker News matched by feed URL", report.SubscribedFeeds)
+	}
+	if len(report.NewFeeds) != 3 || report.NewFeeds[0].Category != "News" || report.NewFeeds[2].Category != "" {
+		t.Errorf("new feeds = %+v, want Lobsters under another feed URL, Quanta and a single Go Blog", report.NewFeeds)
+	}
+	if !slices.Equal(report.NewCategories, []string{"Science"}) {
+		t.Errorf("new categories = %v, want [Science], which Miniflux does not match to science", report.NewCategories)
+	}
+
+	result = callImportOPML(t, minifluxServer, map[string]any{"opml": testOPML, "dry_run": false})
+	if result.IsError || !result.StructuredContent.(*opmlImportReport).Imported {
+		t.Fatalf("import failed: %#v", result.Content)
+	}
+	if len(imported) != 1 || imported[0] != testOPML {
+		t.Errorf("imported documents = %q, want the given OPML", imported)
+	}
+}
+
+func TestImportOPMLRejectsInvalidDocuments(t *testing.T) {
+	minifluxServer := &MinifluxServer{}
+	testCases := []struct {
+		name      string
+		arguments map[string]any
+		argument  string
+		reason    string
+	}{
+		{"missing", map[string]any{}, "opml", reasonRequired},
+		{"not XML", map[string]any{"opml": "feeds"}, "opml", reasonInvalidOPML},
+		{"not OPML", map[string]any{"opml": "<rss><channel/></rss>"}, "opml", reasonInvalidOPML},
+		{"no feeds", map[string]any{"opml": `<opml><body><outline text="Empty"/></body></opml>`}, "opml", reasonInvalidOPML},
+		{"invalid feed URL", map[string]any{"opml": `<opml><body><outline text="Local" xmlUrl="file:///etc/passwd"/></body></opml>`}, "opml", reasonInvalidOPML},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			result := callImportOPML(t, minifluxServer, testCase.arguments)
+			structured, _ := result.StructuredContent.(map[string]any)
+			argErr, ok := structured["error"].(*argumentError)
+			if !result.IsError || !ok || argErr.Argument != testCase.argument || argErr.Reason != testCase.reason {
+				t.Errorf("result = %#v, want a %s error on %s", result, testCase.reason, testCase.argument)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"miniflux.app/v2/client"
+)
+
+// Structured tool results. MCP requires structured content to be a JSON
+// object, so list results are wrapped in a named field while the text content
+// keeps the plain Miniflux representation.
+
+type feedList struct {
+	Feeds client.Feeds `json:"feeds"`
+}
+
+type categoryList struct {
+	Categories client.Categories `json:"categories"`
+}
+
+type userList struct {
+	Users client.Users `json:"users"`
+}
+
+type apiKeyList struct {
+	APIKeys client.APIKeys `json:"api_keys"`
+}
+
+// feedsResult returns feeds as text and structured content, projected to the
+// selected fields when there are any.
+func feedsResult(feeds client.Feeds, selection fieldSelection) *mcp.CallToolResult {
+	var list any = feeds
+	if selection != nil {
+		projected, err := projectList(feeds, selection)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to project feeds: %v", err))
+		}
+		list = projected
+	}
+
+	feedsJSON, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal feeds: %v", err))
+	}
+	return mcp.NewToolResultStructured(map[string]any{"feeds": list}, string(feedsJSON))
+}
+
+// feedCounters mirrors client.FeedCounters with string keys, which is how
+// JSON encodes the feed IDs and what the generated output schema can express.
+type feedCounters struct {
+	Reads   map[string]int `json:"reads"`
+	Unreads map[string]int `json:"unreads"`
+}
+
+func newFeedCounters(counters *client.FeedCounters) feedCounters {
+	result := feedCounters{
+		Reads:   make(map[string]int, len(counters.ReadCounters)),
+		Unreads: make(map[string]int, len(counters.UnreadCounters)),
+	}
+	for feedID, count := range counters.ReadCounters {
+		result.Reads[strconv.FormatInt(feedID, 10)] = count
+	}
+	for feedID, count := range counters.UnreadCounters {
+		result.Unreads[strconv.FormatInt(feedID, 10)] = count
+	}
+	return result
+}
+
+// userUpdate is the result of update_user: the updated user and the settings
+// whose value changed, keyed by their JSON name.
+type userUpdate struct {
+	User    *client.User           `json:"user"`
+	Changes map[string]fieldChange `json:"changes"`
+}
+
+type fieldChange struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+func newUserUpdate(before, after *client.User) (*userUpdate, error) {
+	beforeFields, err := jsonFields(before)
+	if err != nil {
+		return nil, err
+	}
+	afterFields, err := jsonFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	update := &userUpdate{User: after, Changes: map[string]fieldChange{}}
+	for name, value := range afterFields {
+		if name == "last_login_at" {
+			continue
+		}
+		if previous := beforeFields[name]; !reflect.DeepEqual(previous, value) {
+			update.Changes[name] = fieldChange{Before: previous, After: value}
+		}
+	}
+	return update, nil
+}
+
+// diff renders the changes as a unified diff of the changed settings.
+func (u *userUpdate) diff() string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "User %d (%s) updated", u.User.ID, u.User.Username)
+	if len(u.Changes) == 0 {
+		text.WriteString(", nothing changed")
+		return text.String()
+	}
+	text.WriteString(":")
+	for _, name := range slices.Sorted(maps.Keys(u.Changes)) {
+		change := u.Changes[name]
+		before, _ := json.Marshal(change.Before)
+		after, _ := json.Marshal(change.After)
+		fmt.Fprintf(&text, "\n- %s: %s\n+ %s: %s", name, before, name, after)
+	}
+	return text.String()
+}
+
+// jsonFields returns the fields of value as encoded in JSON.
+func jsonFields(value any) (map[string]any, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/server"
+	"miniflux.app/v2/client"
+)
+
+func newStructuredOutputAPI(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	feed := client.Feed{ID: 42, Title: "Example", FeedURL: "https://example.org/feed.xml", Category: &client.Category{ID: 7, Title: "News"}}
+	entry := client.Entry{ID: 9, FeedID: 42, Status: "unread", Title: "Hello", Feed: &feed, Tags: []string{}}
+	user := client.User{ID: 1, Username: "admin", IsAdmin: true}
+	responses := map[string]any{
+		"GET /v1/feeds":                  client.Feeds{&feed},
+		"GET /v1/feeds/42":               feed,
+		"GET /v1/feeds/42/entries":       client.EntryResultSet{Total: 1, Entries: client.Entries{&entry}},
+		"GET /v1/feeds/42/entries/9":     entry,
+		"GET /v1/entries":                client.EntryResultSet{Total: 1, Entries: client.Entries{&entry}},
+		"GET /v1/entries/9":              entry,
+		"GET /v1/categories":             client.Categories{feed.Category},
+		"GET /v1/categories/7/feeds":     client.Feeds{&feed},
+		"GET /v1/categories/7/entries":   client.EntryResultSet{Total: 1, Entries: client.Entries{&entry}},
+		"GET /v1/categories/7/entries/9": entry,
+		"GET /v1/users":                  client.Users{user},
+		"GET /v1/me":                     user,
+		"GET /v1/feeds/counters":         client.FeedCounters{ReadCounters: map[int64]int{42: 3}, UnreadCounters: map[int64]int{42: 1}},
+		"GET /v1/api-keys":               client.APIKeys{{ID: 5, Description: "cli"}},
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response, ok := responses[r.Method+" "+r.URL.Path]
+		if !ok {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			t.Errorf("encode response body: %v", err)
+		}
+	}))
+}
+
+func TestStructuredToolOutput(t *testing.T) {
+	apiServer := newStructuredOutputAPI(t)
+	defer apiServer.Close()
+
+	minifluxServer := &MinifluxServer{client: client.NewClient(apiServer.URL, "test-api-key")}
+	mcpServer := server.NewMCPServer("test", "test", server.WithOutputSchemaValidation())
+	minifluxServer.RegisterAllTools(mcpServer)
+
+	calls := map[string]map[string]any{
+		"get_feeds":            {},
+		"get_feed":             {"feed_id": 42},
+		"find_duplicate_feeds": {},
+		"feed_health":          {"include_healthy": true},
+		"get_feed_entries":     {"feed_id": 42},
+		"get_feed_entry":       {"feed_id": 42, "entry_id": 9},
+		"get_entries":          {},
+		"get_entry":            {"entry_id": 9},
+		"get_categories":       {},
+		"get_category_feeds":   {"category_id": 7},
+		"get_category_entries": {"category_id": 7},
+		"get_category_entry":   {"category_id": 7, "entry_id": 9},
+		"get_users":            {},
+		"get_me":               {},
+		"fetch_counters":       {},
+		"get_api_keys":         {},
+	}
+	for name, arguments := range calls {
+		t.Run(name, func(t *testing.T) {
+			result := callTool(t, mcpServer, name, arguments)
+			if result.IsError {
+				t.Fatalf("tool returned error: %#v", result.Content)
+			}
+			if result.StructuredContent == nil {
+				t.Fatal("tool returned no structured content")
+			}
+			if len(result.Content) != 1 {
+				t.Fatalf("content length = %d, want a text fallback", len(result.Content))
+			}
+		})
+	}
+}
+
+func TestOutputSchemasDescribeObjects(t *testing.T) {
+	for _, toolDef := range (&MinifluxServer{}).toolDefinitions() {
+		if toolDef.Output == nil {
+			continue
+		}
+		if toolDef.Tool.OutputSchema.Type != "object" {
+			t.Errorf("%s output schema type = %q, want object", toolDef.Tool.Name, toolDef.Tool.OutputSchema.Type)
+		}
+	}
+}
+
+func TestFeedCountersUseStringKeys(t *testing.T) {
+	counters := newFeedCounters(&client.FeedCounters{
+		ReadCounters:   map[int64]int{42: 3},
+		UnreadCounters: map[int64]int{42: 1, 7: 2},
+	})
+	if counters.Reads["42"] != 3 || counters.Unreads["42"] != 1 || counters.Unreads["7"] != 2 {
+		t.Errorf("counters = %#v", counters)
+	}
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// fieldSelection is a tree of selected JSON fields parsed from dotted paths
+// such as "feed.title". A nil subtree selects the whole value.
+type fieldSelection map[string]fieldSelection
+
+// parseFieldSelection parses the fields argument of a tool against the JSON
+// representation of itemType. It returns nil when no field is selected.
+func parseFieldSelection(fields []string, itemType reflect.Type) (fieldSelection, error) {
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	selection := fieldSelection{}
+	for _, path := range fields {
+		if err := validateFieldPath(itemType, path); err != nil {
+			return nil, &argumentError{Argument: "fields", Reason: reasonUnknownField, Message: err.Error()}
+		}
+
+		node := selection
+		segments := strings.Split(path, ".")
+		for i, segment := range segments {
+			child, seen := node[segment]
+			if seen && child == nil {
+				// A parent path already selects the whole value.
+				break
+			}
+			if i == len(segments)-1 {
+				node[segment] = nil
+				break
+			}
+			if child == nil {
+				child = fieldSelection{}
+				node[segment] = child
+			}
+			node = child
+		}
+	}
+	return selection, nil
+}
+
+// validateFieldPath checks that every segment of path names a JSON field,
+// looking through pointers and into the elements of slices.
+func validateFieldPath(itemType reflect.Type, path string) error {
+	current := itemType
+	for _, segment := range strings.Split(path, ".") {
+		for current.Kind() == reflect.Pointer || current.Kind() == reflect.Slice {
+			current = current.Elem()
+		}
+		if current.Kind() != reflect.Struct {
+			return fmt.Errorf("field %q has no nested field %q", path, segment)
+		}
+		next, ok := jsonFieldType(current, segment)
+		if !ok {
+			return fmt.Errorf("unknown field %q", path)
+		}
+		current = next
+	}
+	return nil
+}
+
+func jsonFieldType(structType reflect.Type, name string) (reflect.Type, bool) {
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		tagName, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if tagName == "-" {
+			continue
+		}
+		if tagName == "" {
+			tagName = field.Name
+		}
+		if tagName == name {
+			return field.Type, true
+		}
+	}
+	return nil, false
+}
+
+// projectList encodes items as JSON and keeps only the selected fields of
+// each item.
+func projectList(items any, selection fieldSelection) ([]any, error) {
+	itemsJSON, err := json.Marshal(items)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(itemsJSON))
+	decoder.UseNumber()
+	var decoded []any
+	if err := decoder.Decode(&decoded); err != nil {
+		return nil, err
+	}
+
+	projected := make([]any, len(decoded))
+	for i, item := range decoded {
+		projected[i] = projectValue(item, selection)
+	}
+	return projected, nil
+}
+
+func projectValue(value any, selection fieldSelection) any {
+	if selection == nil {
+		return value
+	}
+	switch typed := value.(type) {
+	case map[string]any:
+		projected := make(map[string]any, len(selection))
+		for name, child := range selection {
+			if fieldValue, ok := typed[name]; ok {
+				projected[name] = projectValue(fieldValue, child)
+			}
+		}
+		return projected
+	case []any:
+		projected := make([]any, len(typed))
+		for i, item := range typed {
+			projected[i] = projectValue(item, selection)
+		}
+		return projected
+	default:
+		return value
+	}
+}
+
+// projectableOutputSchema declares T as the output schema of a tool whose
+// listProperty items can be projected with the fields argument, so nothing
+// inside those items is required.
+func projectableOutputSchema[T any](listProperty string) mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithOutputSchema[T]()(tool)
+		if list, ok := tool.OutputSchema.Properties[listProperty].(map[string]any); ok {
+			removeRequired(list["items"])
+		}
+	}
+}
+
+func removeRequired(schema any) {
+	switch typed := schema.(type) {
+	case map[string]any:
+		delete(typed, "required")
+		for _, value := range typed {
+			removeRequired(value)
+		}
+	case []any:
+		for _, value := range typed {
+			removeRequired(value)
+		}
+	}
+}
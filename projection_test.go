@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/server"
+	"miniflux.app/v2/client"
+)
+
+func TestParseFieldSelection(t *testing.T) {
+	entryType := reflect.TypeFor[client.Entry]()
+
+	selection, err := parseFieldSelection([]string{"id", "feed.title", "feed.category.id", "enclosures.url", "tags"}, entryType)
+	if err != nil {
+		t.Fatalf("parseFieldSelection returned error: %v", err)
+	}
+	want := fieldSelection{
+		"id":         nil,
+		"feed":       {"title": nil, "category": {"id": nil}},
+		"enclosures": {"url": nil},
+		"tags":       nil,
+	}
+	if !reflect.DeepEqual(selection, want) {
+		t.Errorf("selection = %#v, want %#v", selection, want)
+	}
+
+	selection, err = parseFieldSelection([]string{"feed.title", "feed", "feed.id"}, entryType)
+	if err != nil || !reflect.DeepEqual(selection, fieldSelection{"feed": nil}) {
+		t.Errorf("selection = %#v, %v, want the whole feed", selection, err)
+	}
+
+	for _, fields := range [][]string{{"unknown"}, {"feed.unknown"}, {"title.length"}, {""}} {
+		_, err := parseFieldSelection(fields, entryType)
+		var argErr *argumentError
+		if !errors.As(err, &argErr) || argErr.Argument != "fields" || argErr.Reason != reasonUnknownField {
+			t.Errorf("parseFieldSelection(%q) error = %v, want %s", fields, err, reasonUnknownField)
+		}
+	}
+}
+
+func TestProjectList(t *testing.T) {
+	entries := client.Entries{{
+		ID:         9007199254740993,
+		Title:      "Hello",
+		Feed:       &client.Feed{ID: 42, Title: "Example", Category: &client.Category{ID: 7, Title: "News"}},
+		Enclosures: client.Enclosures{{ID: 1, URL: "https://example.org/a.mp3"}, {ID: 2, URL: "https://example.org/b.mp3"}},
+	}}
+	selection := fieldSelection{"id": nil, "feed": {"category": {"title": nil}}, "enclosures": {"url": nil}}
+
+	projected, err := projectList(entries, selection)
+	if err != nil {
+		t.Fatalf("projectList returned error: %v", err)
+	}
+	projectedJSON, err := json.Marshal(projected)
+	if err != nil {
+		t.Fatalf("marshal projection: %v", err)
+	}
+	want := `[{"enclosures":[{"url":"https://example.org/a.mp3"},{"url":"https://example.org/b.mp3"}],"feed":{"category":{"title":"News"}},"id":9007199254740993}]`
+	if string(projectedJSON) != want {
+		t.Errorf("projection = %s, want %s", projectedJSON, want)
+	}
+}
+
+func TestFieldProjectionInTools(t *testing.T) {
+	feed := client.Feed{ID: 42, Title: "Example", FeedURL: "https://example.org/feed.xml", Category: &client.Category{ID: 7, Title: "News"}}
+	entry := client.Entry{ID: 9, Title: "Hello", URL: "https://example.org/hello", Content: "<p>long</p>", Feed: &feed}
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var response any
+		switch r.URL.Path {
+		case "/v1/entries":
+			response = client.EntryResultSet{Total: 1, Entries: client.Entries{&entry}}
+		case "/v1/feeds":
+			response = client.Feeds{&feed}
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			t.Errorf("encode response body: %v", err)
+		}
+	}))
+	defer apiServer.Close()
+
+	minifluxServer := &MinifluxServer{client: client.NewClient(apiServer.URL, "test-api-key")}
+	mcpServer := server.NewMCPServer("test", "test", server.WithOutputSchemaValidation())
+	minifluxServer.RegisterAllTools(mcpServer)
+
+	result := callTool(t, mcpServer, "get_entries", map[string]any{"fields": []string{"id", "title", "feed.title"}})
+	if result.IsError {
+		t.Fatalf("get_entries returned error: %#v", result.Content)
+	}
+	structuredJSON, _ := json.Marshal(result.StructuredContent)
+	if want := `{"entries":[{"feed":{"title":"Example"},"id":9,"title":"Hello"}],"total":1}`; string(structuredJSON) != want {
+		t.Errorf("get_entries structured content = %s, want %s", structuredJSON, want)
+	}
+
+	result = callTool(t, mcpServer, "get_feeds", map[string]any{"fields": []string{"id", "category.title"}})
+	if result.IsError {
+		t.Fatalf("get_feeds returned error: %#v", result.Content)
+	}
+	structuredJSON, _ = json.Marshal(result.StructuredContent)
+	if want := `{"feeds":[{"category":{"title":"News"},"id":42}]}`; string(structuredJSON) != want {
+		t.Errorf("get_feeds structured content = %s, want %s", structuredJSON, want)
+	}
+
+	result = callTool(t, mcpServer, "get_entries", map[string]any{"fields": []string{"id"}, "format": "markdown"})
+	if !result.IsError {
+		t.Error("get_entries accepted fields with the markdown format")
+	}
+}
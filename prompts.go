@@ -0,0 +1,250 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"miniflux.app/v2/client"
+)
+
+const (
+	defaultDigestWindow = 24 * time.Hour
+	maxDigestEntries    = 50
+	defaultTriageLimit  = 20
+	maxTriageLimit      = 100
+)
+
+// PromptDefinition pairs a prompt with its handler.
+type PromptDefinition struct {
+	Prompt  mcp.Prompt
+	Handler server.PromptHandlerFunc
+}
+
+func (s *MinifluxServer) RegisterAllPrompts(mcpServer *server.MCPServer) {
+	for _, promptDef := range s.promptDefinitions() {
+		mcpServer.AddPrompt(promptDef.Prompt, promptDef.Handler)
+	}
+}
+
+func (s *MinifluxServer) promptDefinitions() []PromptDefinition {
+	return []PromptDefinition{
+		{
+			Prompt: mcp.NewPrompt("daily_digest",
+				mcp.WithPromptTitle("Daily Digest"),
+				mcp.WithPromptDescription("Summarize recent unread entries, grouped by feed"),
+				mcp.WithArgument("category", mcp.ArgumentDescription("Category ID or title; all categories when omitted")),
+				mcp.WithArgument("since", mcp.ArgumentDescription("Include entries published after this date (YYYY-MM-DD), time (RFC 3339) or duration ago (for example 12h); defaults to 24h")),
+			),
+			Handler: s.DailyDigestPrompt,
+		},
+		{
+			Prompt: mcp.NewPrompt("triage_unread",
+				mcp.WithPromptTitle("Triage Unread"),
+				mcp.WithPromptDescription("Decide what to read, keep or dismiss among the newest unread entries"),
+				mcp.WithArgument("limit", mcp.ArgumentDescription(fmt.Sprintf("Number of entries to triage, up to %d; defaults to %d", maxTriageLimit, defaultTriageLimit))),
+			),
+			Handler: s.TriageUnreadPrompt,
+		},
+		{
+			Prompt: mcp.NewPrompt("find_dead_feeds",
+				mcp.WithPromptTitle("Find Dead Feeds"),
+				mcp.WithPromptDescription("Find feeds that fail to update or are disabled"),
+			),
+			Handler: s.FindDeadFeedsPrompt,
+		},
+		{
+			Prompt: mcp.NewPrompt("summarize_entry",
+				mcp.WithPromptTitle("Summarize Entry"),
+				mcp.WithPromptDescription("Summarize a single entry"),
+				mcp.WithArgument("entry_id", mcp.ArgumentDescription("Entry ID"), mcp.RequiredArgument()),
+			),
+			Handler: s.SummarizeEntryPrompt,
+		},
+	}
+}
+
+func (s *MinifluxServer) DailyDigestPrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	since, err := parsePromptSince(request.Params.Arguments["since"], time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	filter := &client.Filter{
+		Status:         client.EntryStatusUnread,
+		PublishedAfter: since.Unix(),
+		Order:          "published_at",
+		Direction:      "asc",
+		Limit:          maxDigestEntries,
+	}
+	scope := "all categories"
+	if value := request.Params.Arguments["category"]; value != "" {
+		categoryID, title, err := s.promptCategory(value)
+		if err != nil {
+			return nil, err
+		}
+		filter.CategoryID = categoryID
+		scope = fmt.Sprintf("the %q category", title)
+	}
+
+	entries, err := s.client.Entries(filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch entries: %w", err)
+	}
+
+	var instructions strings.Builder
+	fmt.Fprintf(&instructions, "Write a digest of the unread entries published in %s since %s.", scope, since.UTC().Format(entryDateLayout))
+	if len(entries.Entries) == 0 {
+		instructions.WriteString(" There are none, so just say so.")
+		return promptResult("Daily digest", instructions.String(), nil), nil
+	}
+	instructions.WriteString(" Group them by feed, summarize each entry in one or two sentences and open with the most important stories.")
+	if entries.Total > len(entries.Entries) {
+		fmt.Fprintf(&instructions, " Only the oldest %d of %d entries are included; mention that the rest remain unread.", len(entries.Entries), entries.Total)
+	}
+	if s.toolAvailable("update_entries_status") {
+		instructions.WriteString(" Then mark the summarized entries as read with a single update_entries_status call listing their entry_ids.")
+	}
+	return promptResult("Daily digest", instructions.String(), entries.Entries), nil
+}
+
+func (s *MinifluxServer) TriageUnreadPrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	limit := defaultTriageLimit
+	if value := request.Params.Arguments["limit"]; value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > maxTriageLimit {
+			return nil, fmt.Errorf("limit must be an integer between 1 and %d, got %q", maxTriageLimit, value)
+		}
+		limit = parsed
+	}
+
+	entries, err := s.client.Entries(&client.Filter{
+		Status:    client.EntryStatusUnread,
+		Order:     "published_at",
+		Direction: "desc",
+		Limit:     limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch entries: %w", err)
+	}
+
+	var instructions strings.Builder
+	fmt.Fprintf(&instructions, "Triage my %d newest unread entries (%d unread in total).", len(entries.Entries), entries.Total)
+	if len(entries.Entries) == 0 {
+		instructions.WriteString(" There is nothing to triage, so just say so.")
+		return promptResult("Triage unread entries", instructions.String(), nil), nil
+	}
+	instructions.WriteString(" For each entry, recommend reading it now, keeping it for later or dismissing it, with a one-line reason, as a list ordered by priority.")
+	var actions []string
+	if s.toolAvailable("set_starred") {
+		actions = append(actions, "star the entries to keep with set_starred")
+	} else if s.toolAvailable("toggle_starred") {
+		actions = append(actions, "star the entries to keep with toggle_starred")
+	}
+	if s.toolAvailable("update_entry_status") {
+		actions = append(actions, "mark the dismissed entries as read with update_entry_status")
+	}
+	if len(actions) > 0 {
+		fmt.Fprintf(&instructions, " Once I confirm, %s.", strings.Join(actions, " and "))
+	}
+	return promptResult("Triage unread entries", instructions.String(), entries.Entries), nil
+}
+
+func (s *MinifluxServer) FindDeadFeedsPrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	feeds, err := s.client.Feeds()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch feeds: %w", err)
+	}
+	health, err := projectList(feeds, fieldSelection{
+		"id": nil, "title": nil, "feed_url": nil, "site_url": nil, "category": {"title": nil},
+		"checked_at": nil, "disabled": nil, "parsing_error_count": nil, "parsing_error_message": nil,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal feeds: %w", err)
+	}
+	feedsContents, err := jsonResourceContents(resourceFeedsURI, health)
+	if err != nil {
+		return nil, err
+	}
+
+	var instructions strings.Builder
+	fmt.Fprintf(&instructions, "Review my %d feeds and list the ones that look dead or broken: feeds with parsing errors, disabled feeds and feeds not checked for a long time.", len(feeds))
+	instructions.WriteString(" For each, explain the problem and suggest a fix, such as a new feed URL found on the site or removing the feed.")
+	if s.toolAvailable("update_feed") && s.toolAvailable("delete_feed") {
+		instructions.WriteString(" Apply the fixes with update_feed or delete_feed only after I confirm them.")
+	}
+	return mcp.NewGetPromptResult("Find dead feeds", []mcp.PromptMessage{
+		mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(instructions.String())),
+		mcp.NewPromptMessage(mcp.RoleUser, mcp.NewEmbeddedResource(feedsContents[0])),
+	}), nil
+}
+
+func (s *MinifluxServer) SummarizeEntryPrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	value := request.Params.Arguments["entry_id"]
+	entryID, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || entryID < 1 {
+		return nil, fmt.Errorf("entry_id must be a positive integer, got %q", value)
+	}
+
+	entry, err := s.client.Entry(entryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch entry: %w", err)
+	}
+
+	instructions := fmt.Sprintf("Summarize the entry %q in a short paragraph, followed by its key points as a list.", entry.Title)
+	return promptResult("Summarize entry", instructions, client.Entries{entry}), nil
+}
+
+// promptCategory resolves the category argument of a prompt like the
+// category argument of tools, and returns the ID and title of the category.
+func (s *MinifluxServer) promptCategory(value string) (int64, string, error) {
+	categories := sync.OnceValues(s.categoryMatches)
+	categoryID, err := resolveReference("category", nil, &value, categories)
+	if err != nil {
+		return 0, "", err
+	}
+	matches, _ := categories()
+	for _, match := range matches {
+		if match.ID == categoryID {
+			return categoryID, match.Labels[0], nil
+		}
+	}
+	return 0, "", fmt.Errorf("no category has the ID %d", categoryID)
+}
+
+// parsePromptSince parses the since argument of a prompt: a date, an RFC 3339
+// time or a duration before now. An empty value means defaultDigestWindow ago.
+func parsePromptSince(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return now.Add(-defaultDigestWindow), nil
+	}
+	if date, err := time.ParseInLocation(time.DateOnly, value, time.Local); err == nil {
+		return date, nil
+	}
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return parsed, nil
+	}
+	if duration, err := time.ParseDuration(value); err == nil && duration > 0 {
+		return now.Add(-duration), nil
+	}
+	return time.Time{}, fmt.Errorf("since must be a date (YYYY-MM-DD), an RFC 3339 time or a positive duration such as 12h, got %q", value)
+}
+
+// promptResult builds a prompt of instructions followed by one message per
+// entry, embedding the entry resource rendered as Markdown.
+func promptResult(description, instructions string, entries client.Entries) *mcp.GetPromptResult {
+	messages := []mcp.PromptMessage{mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(instructions))}
+	for _, entry := range entries {
+		messages = append(messages, mcp.NewPromptMessage(mcp.RoleUser, mcp.NewEmbeddedResource(mcp.TextResourceContents{
+			URI:      fmt.Sprintf("miniflux://entry/%d", entry.ID),
+			MIMEType: "text/markdown",
+			Text:     renderEntry(entry, false),
+		})))
+	}
+	return mcp.NewGetPromptResult(description, messages)
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"miniflux.app/v2/client"
+)
+
+func getPrompt(t *testing.T, mcpServer *server.MCPServer, name string, arguments map[string]string) (mcp.GetPromptResult, bool) {
+	t.Helper()
+
+	response, ok := sendRequest(t, mcpServer, "prompts/get", map[string]any{"name": name, "arguments": arguments}).(mcp.JSONRPCResponse)
+	if !ok {
+		return mcp.GetPromptResult{}, false
+	}
+	return response.Result.(mcp.GetPromptResult), true
+}
+
+func promptText(result mcp.GetPromptResult) string {
+	var texts []string
+	for _, message := range result.Messages {
+		switch content := message.Content.(type) {
+		case mcp.TextContent:
+			texts = append(texts, content.Text)
+		case mcp.EmbeddedResource:
+			texts = append(texts, content.Resource.(mcp.TextResourceContents).Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
+func TestPrompts(t *testing.T) {
+	feed := client.Feed{ID: 42, Title: "Example", ParsingErrorCount: 3, ParsingErrorMsg: "404 Not Found"}
+	entry := client.Entry{ID: 9, Title: "Hello", Status: "unread", Content: "<p>Hi <b>there</b></p>", Feed: &feed}
+	var entriesQuery url.Values
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response any
+		switch r.URL.Path {
+		case "/v1/categories":
+			response = client.Categories{{ID: 7, Title: "News"}}
+		case "/v1/entries":
+			entriesQuery = r.URL.Query()
+			response = client.EntryResultSet{Total: 3, Entries: client.Entries{&entry}}
+		case "/v1/entries/9":
+			response = entry
+		case "/v1/feeds":
+			response = client.Feeds{&feed}
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	defer apiServer.Close()
+
+	minifluxServer := &MinifluxServer{client: client.NewClient(apiServer.URL, "test-api-key")}
+	mcpServer := newMCPServer(minifluxServer)
+
+	response := sendRequest(t, mcpServer, "prompts/list", map[string]any{}).(mcp.JSONRPCResponse)
+	listed := map[string]bool{}
+	for _, prompt := range response.Result.(mcp.ListPromptsResult).Prompts {
+		listed[prompt.Name] = true
+	}
+	for _, name := range []string{"daily_digest", "triage_unread", "find_dead_feeds", "summarize_entry"} {
+		if !listed[name] {
+			t.Errorf("prompt %s is not listed", name)
+		}
+	}
+
+	digest, ok := getPrompt(t, mcpServer, "daily_digest", map[string]string{"category": "news", "since": "2026-01-02"})
+	if !ok {
+		t.Fatal("daily_digest failed")
+	}
+	if entriesQuery.Get("category_id") != "7" || entriesQuery.Get("status") != "unread" || entriesQuery.Get("published_after") == "" {
+		t.Errorf("daily_digest queried entries with %v", entriesQuery)
+	}
+	if text := promptText(digest); !strings.Contains(text, `"News" category`) || !strings.Contains(text, "update_entries_status") || !strings.Contains(text, "Hi **there**") {
+		t.Errorf("daily_digest prompt = %q", text)
+	}
+	if embedded := digest.Messages[1].Content.(mcp.EmbeddedResource); embedded.Resource.(mcp.TextResourceContents).URI != "miniflux://entry/9" {
+		t.Errorf("daily_digest embedded %#v", embedded.Resource)
+	}
+	if digest, ok := getPrompt(t, mcpServer, "daily_digest", map[string]string{"category": "7"}); !ok || !strings.Contains(promptText(digest), `"News" category`) {
+		t.Errorf("daily_digest of category 7 = %#v, want the News category", digest)
+	}
+	for _, category := range []string{"Sports", "70"} {
+		if _, ok := getPrompt(t, mcpServer, "daily_digest", map[string]string{"category": category}); ok {
+			t.Errorf("daily_digest accepted the unknown category %q", category)
+		}
+	}
+
+	triage, ok := getPrompt(t, mcpServer, "triage_unread", map[string]string{"limit": "5"})
+	if !ok || entriesQuery.Get("limit") != "5" || entriesQuery.Get("direction") != "desc" {
+		t.Fatalf("triage_unread queried entries with %v", entriesQuery)
+	}
+	if text := promptText(triage); !strings.Contains(text, "3 unread in total") || !strings.Contains(text, "set_starred") {
+		t.Errorf("triage_unread prompt = %q", text)
+	}
+	if _, ok := getPrompt(t, mcpServer, "triage_unread", map[string]string{"limit": "0"}); ok {
+		t.Error("triage_unread accepted limit 0")
+	}
+
+	deadFeeds, ok := getPrompt(t, mcpServer, "find_dead_feeds", nil)
+	if text := promptText(deadFeeds); !ok || !strings.Contains(text, `"parsing_error_message": "404 Not Found"`) {
+		t.Errorf("find_dead_feeds prompt = %q", text)
+	}
+
+	summary, ok := getPrompt(t, mcpServer, "summarize_entry", map[string]string{"entry_id": "9"})
+	if text := promptText(summary); !ok || !strings.Contains(text, `"Hello"`) || !strings.Contains(text, "# Hello") {
+		t.Errorf("summarize_entry prompt = %q", text)
+	}
+	if _, ok := getPrompt(t, mcpServer, "summarize_entry", map[string]string{"entry_id": "abc"}); ok {
+		t.Error("summarize_entry accepted an invalid ID")
+	}
+
+	readOnly := newMCPServer(&MinifluxServer{client: minifluxServer.client, tools: toolConfig{ReadOnly: true}})
+	digest, _ = getPrompt(t, readOnly, "daily_digest", nil)
+	if text := promptText(digest); strings.Contains(text, "update_entries_status") {
+		t.Errorf("read-only daily_digest prompt suggests a disabled tool: %q", text)
+	}
+}
+
+func TestParsePromptSince(t *testing.T) {
+	now := time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		value string
+		want  time.Time
+	}{
+		{"", now.Add(-24 * time.Hour)},
+		{"6h", now.Add(-6 * time.Hour)},
+		{"2026-03-01T08:00:00Z", time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)},
+		{"2026-03-01", time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local)},
+	}
+	for _, testCase := range testCases {
+		got, err := parsePromptSince(testCase.value, now)
+		if err != nil || !got.Equal(testCase.want) {
+			t.Errorf("parsePromptSince(%q) = %v, %v, want %v", testCase.value, got, err, testCase.want)
+		}
+	}
+	for _, value := range []string{"yesterday", "-6h"} {
+		if _, err := parsePromptSince(value, now); err == nil {
+			t.Errorf("parsePromptSince(%q) succeeded", value)
+		}
+	}
+}
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"miniflux.app/v2/client"
+)
+
+// maxReferenceSuggestions bounds the similar names listed when a reference
+// matches nothing.
+const maxReferenceSuggestions = 5
+
+// feedReference identifies a feed either by feed_id or by feed, which also
+// accepts the title, site URL or feed URL of the feed. Argument structs embed
+// it and handlers resolve it with resolveFeed.
+type feedReference struct {
+	FeedID *int64  `json:"feed_id" minimum:"1" description:"The ID of the feed"`
+	Feed   *string `json:"feed" description:"The feed ID, exact title, site URL or feed URL; an alternative to feed_id"`
+}
+
+// categoryReference identifies a category either by category_id or by
+// category, which also accepts the title of the category.
+type categoryReference struct {
+	CategoryID *int64  `json:"category_id" minimum:"1" description:"The ID of the category"`
+	Category   *string `json:"category" description:"The category ID or exact title; an alternative to category_id"`
+}
+
+func (r feedReference) given() bool {
+	return r.FeedID != nil || r.Feed != nil
+}
+
+func (r categoryReference) given() bool {
+	return r.CategoryID != nil || r.Category != nil
+}
+
+// referenceMatch is an object a name reference may resolve to.
+type referenceMatch struct {
+	ID          int64
+	Description string
+	Labels      []string
+}
+
+// resolveFeed returns the ID of the feed a tool call refers to. Names are
+// matched against the current feeds; a name matching several feeds is an
+// ambiguity error listing them.
+func (s *MinifluxServer) resolveFeed(ref feedReference) (int64, error) {
+	return resolveReference("feed", ref.FeedID, ref.Feed, s.subscribedFeedMatches)
+}
+
+// resolveCategory returns the ID of the category a tool call refers to.
+func (s *MinifluxServer) resolveCategory(ref categoryReference) (int64, error) {
+	return resolveReference("category", ref.CategoryID, ref.Category, s.categoryMatches)
+}
+
+func (s *MinifluxServer) subscribedFeedMatches() ([]referenceMatch, error) {
+	feeds, err := s.client.Feeds()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch feeds: %w", err)
+	}
+	return feedMatches(feeds), nil
+}
+
+func (s *MinifluxServer) categoryMatches() ([]referenceMatch, error) {
+	categories, err := s.client.Categories()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch categories: %w", err)
+	}
+	matches := make([]referenceMatch, len(categories))
+	for i, category := range categories {
+		matches[i] = referenceMatch{
+			ID:          category.ID,
+			Description: fmt.Sprintf("%d (%s)", category.ID, category.Title),
+			Labels:      []string{category.Title},
+		}
+	}
+	return matches, nil
+}
+
+func feedMatches(feeds client.Feeds) []referenceMatch {
+	matches := make([]referenceMatch, len(feeds))
+	for i, feed := range feeds {
+		matches[i] = referenceMatch{
+			ID:          feed.ID,
+			Description: fmt.Sprintf("%d (%s, %s)", feed.ID, feed.Title, feed.FeedURL),
+			Labels:      []string{feed.Title, normalizeReferenceURL(feed.SiteURL), normalizeReferenceURL(feed.FeedURL)},
+		}
+	}
+	return matches
+}
+
+// resolveReference resolves the kind_id or kind argument of a kind of object.
+func resolveReference(kind string, id *int64, name *string, list func() ([]referenceMatch, error)) (int64, error) {
+	return resolveReferenceArguments(kind, kind+"_id", kind, id, name, list)
+}
+
+// resolveReferences resolves a list argument naming objects of a kind, the
+// alternative to the idArgument list of IDs. The objects are listed once.
+func resolveReferences(kind, idArgument, argument string, names []string, list func() ([]referenceMatch, error)) ([]int64, error) {
+	list = sync.OnceValues(list)
+	ids := make([]int64, len(names))
+	for i := range names {
+		id, err := resolveReferenceArguments(kind, idArgument, argument, nil, &names[i], list)
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
+
+// resolveReferenceArguments resolves the ID argument or the name argument of
+// a kind of object. Names are compared, ignoring case, with the labels of the
+// objects returned by list. A name made of digits is also taken as an ID, so
+// a title such as "1984" matching another object makes it ambiguous.
+func resolveReferenceArguments(kind, idArgument, argument string, id *int64, name *string, list func() ([]referenceMatch, error)) (int64, error) {
+	switch {
+	case id != nil && name != nil:
+		return 0, &argumentError{
+			Argument: argument,
+			Reason:   reasonInvalidArguments,
+			Message:  fmt.Sprintf("use either %s or %s, not both", idArgument, argument),
+		}
+	case id != nil:
+		return *id, nil
+	case name == nil:
+		return 0, &argumentError{
+			Argument: idArgument,
+			Reason:   reasonRequired,
+			Message:  fmt.Sprintf("%s or %s is required", idArgument, argument),
+		}
+	}
+
+	value := strings.TrimSpace(*name)
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || parsed <= 0 {
+		parsed = 0
+	}
+
+	candidates, err := list()
+	if err != nil {
+		return 0, err
+	}
+	var matched []referenceMatch
+	normalized := normalizeReferenceURL(value)
+	for _, candidate := range candidates {
+		if parsed != 0 && candidate.ID == parsed {
+			matched = append(matched, candidate)
+			continue
+		}
+		for _, label := range candidate.Labels {
+			if label != "" && (strings.EqualFold(label, value) || label == normalized) {
+				matched = append(matched, candidate)
+				break
+			}
+		}
+	}
+
+	switch {
+	case len(matched) == 1:
+		return matched[0].ID, nil
+	case len(matched) == 0 && parsed != 0:
+		return parsed, nil
+	case len(matched) == 0:
+		suggestions := similarReferences(candidates, value)
+		message := fmt.Sprintf("no %s matches %q", kind, value)
+		if len(suggestions) > 0 {
+			message += "; similar: " + strings.Join(descriptions(suggestions), ", ")
+		}
+		return 0, &argumentError{Argument: argument, Reason: reasonUnknownReference, Message: message, Accepted: referenceIDs(suggestions)}
+	default:
+		return 0, &argumentError{
+			Argument: argument,
+			Reason:   reasonAmbiguousReference,
+			Message:  fmt.Sprintf("%s %q is ambiguous, it matches %s; use %s instead", kind, value, strings.Join(descriptions(matched), ", "), idArgument),
+			Accepted: referenceIDs(matched),
+		}
+	}
+}
+
+// similarReferences returns the candidates whose labels best match value.
+func similarReferences(candidates []referenceMatch, value string) []referenceMatch {
+	byID := make(map[string]referenceMatch, len(candidates))
+	completions := make([]completionCandidate, len(candidates))
+	for i, candidate := range candidates {
+		id := strconv.FormatInt(candidate.ID, 10)
+		byID[id] = candidate
+		completions[i] = completionCandidate{value: id, labels: candidate.Labels}
+	}
+
+	ranked := rankCompletions(completions, value)
+	if len(ranked) > maxReferenceSuggestions {
+		ranked = ranked[:maxReferenceSuggestions]
+	}
+	similar := make([]referenceMatch, len(ranked))
+	for i, id := range ranked {
+		similar[i] = byID[id]
+	}
+	return similar
+}
+
+func descriptions(matches []referenceMatch) []string {
+	described := make([]string, len(matches))
+	for i, match := range matches {
+		described[i] = match.Description
+	}
+	return described
+}
+
+func referenceIDs(matches []referenceMatch) []string {
+	ids := make([]string, len(matches))
+	for i, match := range matches {
+		ids[i] = strconv.FormatInt(match.ID, 10)
+	}
+	return ids
+}
+
+// normalizeReferenceURL lowercases a URL and drops its trailing slash, so
+// URLs copied from a browser match the ones stored by Miniflux.
+func normalizeReferenceURL(value string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(value)), "/")
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"miniflux.app/v2/client"
+)
+
+func newResolverAPI(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response any
+		switch r.URL.Path {
+		case "/v1/feeds":
+			response = client.Feeds{
+				{ID: 1, Title: "Hacker News", SiteURL: "https://news.ycombinator.com/", FeedURL: "https://news.ycombinator.com/rss"},
+				{ID: 2, Title: "Lobsters", SiteURL: "https://lobste.rs", FeedURL: "https://lobste.rs/rss"},
+				{ID: 3, Title: "Go Blog", SiteURL: "https://go.dev/blog", FeedURL: "https://go.dev/blog/feed.atom"},
+				{ID: 4, Title: "Go Blog", SiteURL: "https://go.dev/blog", FeedURL: "https://blog.golang.org/feed.atom"},
+				{ID: 5, Title: "1984", SiteURL: "https://orwell.test", FeedURL: "https://orwell.test/feed"},
+				{ID: 6, Title: "3", SiteURL: "https://three.test", FeedURL: "https://three.test/feed"},
+			}
+		case "/v1/categories":
+			response = client.Categories{{ID: 7, Title: "News"}, {ID: 8, Title: "Programming"}}
+		case "/v1/categories/8/feeds":
+			response = client.Feeds{{ID: 3, Title: "Go Blog"}}
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(apiServer.Close)
+	return apiServer
+}
+
+func TestResolveFeed(t *testing.T) {
+	apiServer := newResolverAPI(t)
+	minifluxServer := &MinifluxServer{client: client.NewClient(apiServer.URL, "test-api-key")}
+	id := func(value int64) *int64 { return &value }
+	name := func(value string) *string { return &value }
+
+	testCases := []struct {
+		name string
+		ref  feedReference
+		want int64
+	}{
+		{"ID", feedReference{FeedID: id(9)}, 9},
+		{"numeric name", feedReference{Feed: name("9")}, 9},
+		{"numeric title", feedReference{Feed: name("1984")}, 5},
+		{"title ignoring case", feedReference{Feed: name("hacker news")}, 1},
+		{"site URL without trailing slash", feedReference{Feed: name("https://news.ycombinator.com")}, 1},
+		{"feed URL", feedReference{Feed: name("https://lobste.rs/rss")}, 2},
+		{"feed URL of a shared title", feedReference{Feed: name("https://blog.golang.org/feed.atom")}, 4},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got, err := minifluxServer.resolveFeed(testCase.ref)
+			if err != nil || got != testCase.want {
+				t.Errorf("resolveFeed = %d, %v, want %d", got, err, testCase.want)
+			}
+		})
+	}
+}
+
+func TestResolveFeedErrors(t *testing.T) {
+	apiServer := newResolverAPI(t)
+	minifluxServer := &MinifluxServer{client: client.NewClient(apiServer.URL, "test-api-key")}
+	id, name, numeric := int64(1), "Go Blog", "3"
+
+	testCases := []struct {
+		name     string
+		ref      feedReference
+		argument string
+		reason   string
+		accepted []string
+	}{
+		{"missing", feedReference{}, "feed_id", reasonRequired, nil},
+		{"both", feedReference{FeedID: &id, Feed: &name}, "feed", reasonInvalidArguments, nil},
+		{"ambiguous", feedReference{Feed: &name}, "feed", reasonAmbiguousReference, []string{"3", "4"}},
+		{"ID and title", feedReference{Feed: &numeric}, "feed", reasonAmbiguousReference, []string{"3", "6"}},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := minifluxServer.resolveFeed(testCase.ref)
+			var argErr *argumentError
+			if !errors.As(err, &argErr) {
+				t.Fatalf("resolveFeed error = %v, want an argument error", err)
+			}
+			if argErr.Argument != testCase.argument || argErr.Reason != testCase.reason || !slices.Equal(argErr.Accepted, testCase.accepted) {
+				t.Errorf("error = %+v, want argument %q with reason %q accepting %v", argErr, testCase.argument, testCase.reason, testCase.accepted)
+			}
+		})
+	}
+
+	unknown := "hackr news"
+	_, err := minifluxServer.resolveFeed(feedReference{Feed: &unknown})
+	var argErr *argumentError
+	if !errors.As(err, &argErr) || argErr.Reason != reasonUnknownReference {
+		t.Fatalf("resolveFeed error = %v, want an unknown reference", err)
+	}
+	if !strings.Contains(argErr.Message, "similar: 1 (Hacker News") || !slices.Equal(argErr.Accepted, []string{"1"}) {
+		t.Errorf("unknown feed error = %+v, want Hacker News suggested", argErr)
+	}
+}
+
+func TestHandlersResolveNames(t *testing.T) {
+	apiServer := newResolverAPI(t)
+	minifluxServer := &MinifluxServer{client: client.NewClient(apiServer.URL, "test-api-key")}
+
+	request := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"category": "programming"}}}
+	result, err := minifluxServer.GetCategoryFeeds(context.Background(), request)
+	if err != nil || result.IsError {
+		t.Fatalf("get_category_feeds by name failed: %v %#v", err, result)
+	}
+
+	request = mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"feed": "Go Blog"}}}
+	result, err = minifluxServer.GetFeed(context.Background(), request)
+	if err != nil {
+		t.Fatalf("GetFeed returned error: %v", err)
+	}
+	structured, _ := result.StructuredContent.(map[string]any)
+	if argErr, ok := structured["error"].(*argumentError); !result.IsError || !ok || argErr.Reason != reasonAmbiguousReference {
+		t.Errorf("get_feed of an ambiguous name = %#v, want an ambiguity error", result)
+	}
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	resourceFeedsURI      = "miniflux://feeds"
+	resourceCategoriesURI = "miniflux://categories"
+	resourceOPMLURI       = "miniflux://opml"
+	resourceCountersURI   = "miniflux://counters"
+	resourceFeedTemplate  = "miniflux://feed/{id}"
+	resourceEntryTemplate = "miniflux://entry/{id}"
+	resourceUserTemplate  = "miniflux://user/{username}"
+)
+
+// ResourceDefinition pairs a resource with its handler.
+type ResourceDefinition struct {
+	Resource mcp.Resource
+	Handler  server.ResourceHandlerFunc
+}
+
+// ResourceTemplateDefinition pairs a resource template with its handler.
+// A template with a Group is only exposed while a tool of that group is.
+type ResourceTemplateDefinition struct {
+	Template mcp.ResourceTemplate
+	Group    string
+	Handler  server.ResourceTemplateHandlerFunc
+}
+
+func (s *MinifluxServer) RegisterAllResources(mcpServer *server.MCPServer) {
+	for _, resourceDef := range s.resourceDefinitions() {
+		mcpServer.AddResource(resourceDef.Resource, resourceDef.Handler)
+	}
+	for _, templateDef := range s.resourceTemplateDefinitions() {
+		if templateDef.Group != "" && !s.groupAvailable(templateDef.Group) {
+			continue
+		}
+		mcpServer.AddResourceTemplate(templateDef.Template, templateDef.Handler)
+	}
+}
+
+func (s *MinifluxServer) resourceDefinitions() []ResourceDefinition {
+	return []ResourceDefinition{
+		{
+			Resource: mcp.NewResource(resourceFeedsURI, "Feeds",
+				mcp.WithResourceDescription("All RSS/Atom feeds"),
+				mcp.WithMIMEType("application/json"),
+			),
+			Handler: s.ReadFeedsResource,
+		},
+		{
+			Resource: mcp.NewResource(resourceCategoriesURI, "Categories",
+				mcp.WithResourceDescription("All feed categories"),
+				mcp.WithMIMEType("application/json"),
+			),
+			Handler: s.ReadCategoriesResource,
+		},
+		{
+			Resource: mcp.NewResource(resourceOPMLURI, "OPML Export",
+				mcp.WithResourceDescription("All feeds exported as OPML"),
+				mcp.WithMIMEType("text/x-opml"),
+			),
+			Handler: s.ReadOPMLResource,
+		},
+		{
+			Resource: mcp.NewResource(resourceCountersURI, "Feed Counters",
+				mcp.WithResourceDescription("Read and unread entry counts per feed ID"),
+				mcp.WithMIMEType("application/json"),
+			),
+			Handler: s.ReadCountersResource,
+		},
+	}
+}
+
+func (s *MinifluxServer) resourceTemplateDefinitions() []ResourceTemplateDefinition {
+	return []ResourceTemplateDefinition{
+		{
+			Template: mcp.NewResourceTemplate(resourceFeedTemplate, "Feed",
+				mcp.WithTemplateDescription("A feed by ID"),
+				mcp.WithTemplateMIMEType("application/json"),
+			),
+			Handler: s.ReadFeedResource,
+		},
+		{
+			Template: mcp.NewResourceTemplate(resourceEntryTemplate, "Entry",
+				mcp.WithTemplateDescription("An entry by ID, rendered as Markdown"),
+				mcp.WithTemplateMIMEType("text/markdown"),
+			),
+			Handler: s.ReadEntryResource,
+		},
+		{
+			Template: mcp.NewResourceTemplate(resourceUserTemplate, "User",
+				mcp.WithTemplateDescription("A user by username (admin only)"),
+				mcp.WithTemplateMIMEType("application/json"),
+			),
+			Group:   toolGroupUsers,
+			Handler: s.ReadUserResource,
+		},
+	}
+}
+
+func (s *MinifluxServer) ReadFeedsResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	feeds, err := s.client.Feeds()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch feeds: %w", err)
+	}
+	return jsonResourceContents(request.Params.URI, feeds)
+}
+
+func (s *MinifluxServer) ReadCategoriesResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	categories, err := s.client.Categories()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch categories: %w", err)
+	}
+	return jsonResourceContents(request.Params.URI, categories)
+}
+
+func (s *MinifluxServer) ReadOPMLResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	opml, err := s.client.Export()
+	if err != nil {
+		return nil, fmt.Errorf("failed to export feeds: %w", err)
+	}
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{URI: request.Params.URI, MIMEType: "text/x-opml", Text: string(opml)},
+	}, nil
+}
+
+func (s *MinifluxServer) ReadCountersResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	counters, err := s.client.FetchCounters()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch feed counters: %w", err)
+	}
+	return jsonResourceContents(request.Params.URI, newFeedCounters(counters))
+}
+
+func (s *MinifluxServer) ReadFeedResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	feedID, err := resourceID(request)
+	if err != nil {
+		return nil, err
+	}
+
+	feed, err := s.client.Feed(feedID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch feed: %w", err)
+	}
+	return jsonResourceContents(request.Params.URI, feed)
+}
+
+func (s *MinifluxServer) ReadEntryResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	entryID, err := resourceID(request)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := s.client.Entry(entryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch entry: %w", err)
+	}
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{URI: request.Params.URI, MIMEType: "text/markdown", Text: renderEntry(entry, false)},
+	}, nil
+}
+
+func (s *MinifluxServer) ReadUserResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	user, err := s.client.UserByUsername(resourceVariable(request, "username"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch user: %w", err)
+	}
+	return jsonResourceContents(request.Params.URI, user)
+}
+
+// resourceID returns the id variable matched by a resource template.
+func resourceID(request mcp.ReadResourceRequest) (int64, error) {
+	value := resourceVariable(request, "id")
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || id < 1 {
+		return 0, fmt.Errorf("invalid ID %q in resource URI %s", value, request.Params.URI)
+	}
+	return id, nil
+}
+
+// resourceVariable returns a variable matched by a resource template, which
+// the server passes as a list of values.
+func resourceVariable(request mcp.ReadResourceRequest, name string) string {
+	switch matched := request.Params.Arguments[name].(type) {
+	case string:
+		return matched
+	case []string:
+		return strings.Join(matched, ",")
+	}
+	return ""
+}
+
+func jsonResourceContents(uri string, value any) ([]mcp.ResourceContents, error) {
+	valueJSON, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal resource: %w", err)
+	}
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{URI: uri, MIMEType: "application/json", Text: string(valueJSON)},
+	}, nil
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"miniflux.app/v2/client"
+)
+
+// sendRequest dispatches a JSON-RPC request through the MCP server.
+func sendRequest(t *testing.T, mcpServer *server.MCPServer, method string, params any) mcp.JSONRPCMessage {
+	t.Helper()
+
+	message, err := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": 1, "method": method, "params": params})
+	if err != nil {
+		t.Fatalf("marshal request: %v", err)
+	}
+	return mcpServer.HandleMessage(context.Background(), message)
+}
+
+func readResource(t *testing.T, mcpServer *server.MCPServer, uri string) mcp.TextResourceContents {
+	t.Helper()
+
+	response, ok := sendRequest(t, mcpServer, "resources/read", map[string]any{"uri": uri}).(mcp.JSONRPCResponse)
+	if !ok {
+		t.Fatalf("resources/read %s failed", uri)
+	}
+	result := response.Result.(mcp.ReadResourceResult)
+	if len(result.Contents) != 1 {
+		t.Fatalf("resources/read %s returned %d contents, want 1", uri, len(result.Contents))
+	}
+	contents, ok := result.Contents[0].(mcp.TextResourceContents)
+	if !ok {
+		t.Fatalf("resources/read %s returned %#v, want text", uri, result.Contents[0])
+	}
+	return contents
+}
+
+func TestResources(t *testing.T) {
+	feed := client.Feed{ID: 42, Title: "Example"}
+	responses := map[string]any{
+		"/v1/feeds":      client.Feeds{&feed},
+		"/v1/feeds/42":   feed,
+		"/v1/categories": client.Categories{{ID: 7, Title: "News"}},
+		"/v1/feeds/counters": client.FeedCounters{
+			ReadCounters:   map[int64]int{42: 2},
+			UnreadCounters: map[int64]int{42: 5},
+		},
+		"/v1/users/alice": client.User{ID: 3, Username: "alice"},
+		"/v1/entries/9":   client.Entry{ID: 9, Title: "Hello", Status: "unread", Content: "<p>Hi <b>there</b></p>", Feed: &feed},
+	}
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/export" {
+			_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><opml version="2.0"></opml>`))
+			return
+		}
+		response, ok := responses[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	defer apiServer.Close()
+
+	mcpServer := newMCPServer(&MinifluxServer{client: client.NewClient(apiServer.URL, "test-api-key")})
+
+	listed := map[string]bool{}
+	response := sendRequest(t, mcpServer, "resources/list", map[string]any{}).(mcp.JSONRPCResponse)
+	for _, resource := range response.Result.(mcp.ListResourcesResult).Resources {
+		listed[resource.URI] = true
+	}
+	response = sendRequest(t, mcpServer, "resources/templates/list", map[string]any{}).(mcp.JSONRPCResponse)
+	for _, template := range response.Result.(mcp.ListResourceTemplatesResult).ResourceTemplates {
+		listed[template.URITemplate.Raw()] = true
+	}
+	for _, uri := range []string{resourceFeedsURI, resourceCategoriesURI, resourceOPMLURI, resourceCountersURI, resourceFeedTemplate, resourceEntryTemplate, resourceUserTemplate} {
+		if !listed[uri] {
+			t.Errorf("%s is not listed", uri)
+		}
+	}
+
+	var feeds client.Feeds
+	if contents := readResource(t, mcpServer, "miniflux://feeds"); json.Unmarshal([]byte(contents.Text), &feeds) != nil || len(feeds) != 1 {
+		t.Errorf("feeds resource = %q", contents.Text)
+	}
+	if contents := readResource(t, mcpServer, "miniflux://categories"); !strings.Contains(contents.Text, `"title": "News"`) {
+		t.Errorf("categories resource = %q", contents.Text)
+	}
+	if contents := readResource(t, mcpServer, "miniflux://opml"); contents.MIMEType != "text/x-opml" || !strings.Contains(contents.Text, "<opml") {
+		t.Errorf("opml resource = %#v", contents)
+	}
+	if contents := readResource(t, mcpServer, "miniflux://counters"); !strings.Contains(contents.Text, `"42": 5`) {
+		t.Errorf("counters resource = %q", contents.Text)
+	}
+	if contents := readResource(t, mcpServer, "miniflux://feed/42"); !strings.Contains(contents.Text, `"title": "Example"`) {
+		t.Errorf("feed resource = %q", contents.Text)
+	}
+	if contents := readResource(t, mcpServer, "miniflux://user/alice"); !strings.Contains(contents.Text, `"username": "alice"`) {
+		t.Errorf("user resource = %q", contents.Text)
+	}
+	contents := readResource(t, mcpServer, "miniflux://entry/9")
+	if contents.MIMEType != "text/markdown" || !strings.Contains(contents.Text, "# Hello") || !strings.Contains(contents.Text, "Hi **there**") {
+		t.Errorf("entry resource = %#v", contents)
+	}
+
+	if _, ok := sendRequest(t, mcpServer, "resources/read", map[string]any{"uri": "miniflux://entry/abc"}).(mcp.JSONRPCError); !ok {
+		t.Error("reading an entry with an invalid ID did not fail")
+	}
+
+	withoutUsers := newMCPServer(&MinifluxServer{client: client.NewClient(apiServer.URL, "test-api-key"), tools: toolConfig{Disabled: []string{toolGroupUsers}}})
+	response = sendRequest(t, withoutUsers, "resources/templates/list", map[string]any{}).(mcp.JSONRPCResponse)
+	for _, template := range response.Result.(mcp.ListResourceTemplatesResult).ResourceTemplates {
+		if template.URITemplate.Raw() == resourceUserTemplate {
+			t.Error("the user resource is listed with the users tools disabled")
+		}
+	}
+	if _, ok := sendRequest(t, withoutUsers, "resources/read", map[string]any{"uri": "miniflux://user/alice"}).(mcp.JSONRPCError); !ok {
+		t.Error("the user resource is readable with the users tools disabled")
+	}
+}
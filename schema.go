@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// inputSchema generates a tool input schema from an argument struct, so the
+// arguments advertised to clients are exactly the ones bindArguments accepts.
+func inputSchema(args any) (mcp.ToolInputSchema, error) {
+	argsType := reflect.TypeOf(args)
+	if argsType == nil || argsType.Kind() != reflect.Struct {
+		return mcp.ToolInputSchema{}, fmt.Errorf("argument type must be a struct, got %T", args)
+	}
+
+	fields, err := argumentFields(argsType)
+	if err != nil {
+		return mcp.ToolInputSchema{}, err
+	}
+
+	schema := mcp.ToolInputSchema{
+		Type:                 "object",
+		Properties:           make(map[string]any, len(fields)),
+		AdditionalProperties: false,
+	}
+	for _, field := range fields {
+		property, err := propertySchema(field.Type, field)
+		if err != nil {
+			return mcp.ToolInputSchema{}, fmt.Errorf("%s.%s: %w", argsType.Name(), field.Name, err)
+		}
+		if field.Description != "" {
+			property["description"] = field.Description
+		}
+		if field.Default != nil {
+			property["default"] = field.Default
+		}
+		schema.Properties[field.Name] = property
+		if field.Required {
+			schema.Required = append(schema.Required, field.Name)
+		}
+	}
+	return schema, nil
+}
+
+// mustInputSchema is like inputSchema but panics on invalid argument structs.
+// Argument structs are static, so a failure is a programming error.
+func mustInputSchema(args any) mcp.ToolInputSchema {
+	schema, err := inputSchema(args)
+	if err != nil {
+		panic(err)
+	}
+	return schema
+}
+
+func propertySchema(fieldType reflect.Type, field argumentField) (map[string]any, error) {
+	if fieldType.Kind() == reflect.Pointer {
+		fieldType = fieldType.Elem()
+	}
+
+	property := map[string]any{}
+	switch fieldType.Kind() {
+	case reflect.String:
+		property["type"] = "string"
+		if len(field.Enum) > 0 {
+			property["enum"] = field.Enum
+		}
+	case reflect.Bool:
+		property["type"] = "boolean"
+	case reflect.Int, reflect.Int64:
+		property["type"] = "integer"
+		if field.Minimum != nil {
+			property["minimum"] = *field.Minimum
+		}
+		if field.Maximum != nil {
+			property["maximum"] = *field.Maximum
+		}
+	case reflect.Slice:
+		items, err := propertySchema(fieldType.Elem(), field)
+		if err != nil {
+			return nil, err
+		}
+		property["type"] = "array"
+		property["items"] = items
+	default:
+		return nil, fmt.Errorf("unsupported argument type %s", fieldType)
+	}
+	return property, nil
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"miniflux.app/v2/client"
+)
+
+// Outcomes of subscribe.
+const (
+	subscriptionCreated        = "subscribed"
+	subscriptionExisting       = "already_subscribed"
+	subscriptionNeedsSelection = "needs_selection"
+)
+
+// feedTypeRanks orders the feed formats discovery may return, preferred
+// first; other formats rank after them.
+var feedTypeRanks = map[string]int{"atom": 0, "rss": 1, "rdf": 2, "json": 3}
+
+// subscriptionResult is the result of subscribe. FeedID is set once the
+// chosen feed is subscribed, whether by this call or before it. CategoryID is
+// only set when a category was given; otherwise Miniflux picks its default.
+type subscriptionResult struct {
+	Status      string                  `json:"status"`
+	Explanation string                  `json:"explanation"`
+	FeedID      int64                   `json:"feed_id,omitempty"`
+	FeedURL     string                  `json:"feed_url,omitempty"`
+	CategoryID  int64                   `json:"category_id,omitempty"`
+	Candidates  []subscriptionCandidate `json:"candidates"`
+}
+
+// subscriptionCandidate is a feed found by discovery, ranked from 1.
+// FeedID is set when the feed is already subscribed.
+type subscriptionCandidate struct {
+	Rank     int    `json:"rank"`
+	Title    string `json:"title"`
+	URL      string `json:"url"`
+	Type     string `json:"type"`
+	SameHost bool   `json:"same_host"`
+	FeedID   int64  `json:"feed_id,omitempty"`
+
+	score int
+}
+
+func (s *MinifluxServer) Subscribe(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args subscribeArgs
+	if err := bindArguments(request, &args); err != nil {
+		return argumentErrorResult(err), nil
+	}
+
+	discovered, err := s.client.Discover(args.URL)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to discover feeds: %v", err)), nil
+	}
+	if len(discovered) == 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("No feed was found at %s", args.URL)), nil
+	}
+	feeds, err := s.client.Feeds()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to fetch feeds: %v", err)), nil
+	}
+	candidates := rankSubscriptions(args.URL, discovered, feeds)
+
+	chosen, explanation, err := chooseSubscription(candidates, args.Pick)
+	if err != nil {
+		return argumentErrorResult(err), nil
+	}
+	result := subscriptionResult{Status: subscriptionNeedsSelection, Explanation: explanation, Candidates: candidates}
+	switch {
+	case chosen == nil:
+	case chosen.FeedID != 0:
+		result.Status, result.FeedID, result.FeedURL = subscriptionExisting, chosen.FeedID, chosen.URL
+		result.Explanation += fmt.Sprintf(" It is already subscribed as feed %d, so nothing was created.", chosen.FeedID)
+	default:
+		var categoryID int64
+		if args.categoryReference.given() {
+			if categoryID, err = s.resolveCategory(args.categoryReference); err != nil {
+				return argumentErrorResult(err), nil
+			}
+		}
+		feedID, err := s.client.CreateFeed(&client.FeedCreationRequest{FeedURL: chosen.URL, CategoryID: categoryID})
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to create feed: %v", err)), nil
+		}
+		result.Status, result.FeedID, result.FeedURL, result.CategoryID = subscriptionCreated, feedID, chosen.URL, categoryID
+		result.Explanation += fmt.Sprintf(" Subscribed as feed %d.", feedID)
+	}
+
+	resultJSON, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal subscription: %v", err)), nil
+	}
+	return mcp.NewToolResultStructured(result, string(resultJSON)), nil
+}
+
+// rankSubscriptions orders discovered feeds: feeds on the host of the page
+// first, then comment feeds last, then by format. Feeds already subscribed
+// are matched by feed URL the way find_duplicate_feeds compares them.
+func rankSubscriptions(pageURL string, discovered client.Subscriptions, feeds client.Feeds) []subscriptionCandidate {
+	subscribed := make(map[string]int64, len(feeds))
+	for _, feed := range feeds {
+		subscribed[feedURLKey(feed.FeedURL)] = feed.ID
+	}
+	pageHost := subscriptionHost(pageURL)
+
+	candidates := make([]subscriptionCandidate, len(discovered))
+	for i, subscription := range discovered {
+		candidate := subscriptionCandidate{
+			Title:    subscription.Title,
+			URL:      subscription.URL,
+			Type:     subscription.Type,
+			SameHost: pageHost != "" && subscriptionHost(subscription.URL) == pageHost,
+			FeedID:   subscribed[feedURLKey(subscription.URL)],
+		}
+		typeRank, ok := feedTypeRanks[subscription.Type]
+		if !ok {
+			typeRank = len(feedTypeRanks)
+		}
+		candidate.score = typeRank
+		if !candidate.SameHost {
+			candidate.score += 100
+		}
+		if strings.Contains(strings.ToLower(subscription.URL+" "+subscription.Title), "comments") {
+			candidate.score += 10
+		}
+		candidates[i] = candidate
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].score < candidates[j].score })
+	for i := range candidates {
+		candidates[i].Rank = i + 1
+	}
+	return candidates
+}
+
+// chooseSubscription picks the candidate to subscribe to: the one given by
+// pick, the only candidate, or the best ranked one when it ranks strictly
+// above the others. It returns nil when the choice is left to the caller.
+func chooseSubscription(candidates []subscriptionCandidate, pick *string) (*subscriptionCandidate, string, error) {
+	if pick != nil {
+		value := strings.TrimSpace(*pick)
+		for i := range candidates {
+			if strconv.Itoa(candidates[i].Rank) == value || normalizeReferenceURL(candidates[i].URL) == normalizeReferenceURL(value) {
+				return &candidates[i], fmt.Sprintf("Picked %s (%s).", candidates[i].URL, candidates[i].Type), nil
+			}
+		}
+		accepted := make([]string, len(candidates))
+		for i, candidate := range candidates {
+			accepted[i] = candidate.URL
+		}
+		return nil, "", &argumentError{
+			Argument: "pick",
+			Reason:   reasonUnknownReference,
+			Message:  fmt.Sprintf("pick %q is neither the rank nor the URL of a discovered feed", value),
+			Accepted: accepted,
+		}
+	}
+
+	best := &candidates[0]
+	switch {
+	case len(candidates) == 1:
+		return best, fmt.Sprintf("Found a single feed, %s (%s).", best.URL, best.Type), nil
+	case best.score < candidates[1].score:
+		return best, fmt.Sprintf("Found %d feeds and chose the best ranked, %s (%s); call again with pick to choose another.", len(candidates), best.URL, best.Type), nil
+	default:
+		return nil, fmt.Sprintf("Found %d equally ranked feeds, so none was subscribed; call again with pick set to the rank or URL of the feed to subscribe to.", len(candidates)), nil
+	}
+}
+
+// subscriptionHost returns the host of a URL without a leading www.
+func subscriptionHost(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"miniflux.app/v2/client"
+)
+
+func TestRankSubscriptions(t *testing.T) {
+	discovered := client.Subscriptions{
+		{Title: "Example via FeedBurner", URL: "https://feeds.feedburner.com/example", Type: "rss"},
+		{Title: "Comments", URL: "https://example.com/comments/feed/", Type: "rss"},
+		{Title: "Example (JSON)", URL: "https://example.com/feed.json", Type: "json"},
+		{Title: "Example", URL: "https://example.com/feed/", Type: "rss"},
+		{Title: "Example (Atom)", URL: "https://www.example.com/feed/atom/", Type: "atom"},
+	}
+	feeds := client.Feeds{{ID: 8, FeedURL: "http://www.example.com/feed.json/"}}
+
+	candidates := rankSubscriptions("https://example.com/blog/", discovered, feeds)
+	var urls []string
+	for _, candidate := range candidates {
+		urls = append(urls, candidate.URL)
+	}
+	want := []string{
+		"https://www.example.com/feed/atom/",
+		"https://example.com/feed/",
+		"https://example.com/feed.json",
+		"https://example.com/comments/feed/",
+		"https://feeds.feedburner.com/example",
+	}
+	if !slices.Equal(urls, want) {
+		t.Errorf("ranked URLs = %v, want %v", urls, want)
+	}
+	if candidates[2].FeedID != 8 || candidates[0].FeedID != 0 || candidates[4].SameHost || candidates[4].Rank != 5 {
+		t.Errorf("candidates = %+v", candidates)
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	discovered := client.Subscriptions{
+		{Title: "Example", URL: "https://example.com/feed/", Type: "rss"},
+		{Title: "Example (Atom)", URL: "https://example.com/atom.xml", Type: "atom"},
+	}
+	subscribed := client.Feeds{}
+	var created []map[string]any
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response any
+		switch {
+		case r.URL.Path == "/v1/discover":
+			response = discovered
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/feeds":
+			response = subscribed
+		case r.URL.Path == "/v1/categories":
+			response = client.Categories{{ID: 1, Title: "All"}, {ID: 4, Title: "Blogs"}}
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/feeds":
+			var body map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			created = append(created, body)
+			w.WriteHeader(http.StatusCreated)
+			response = map[string]int64{"feed_id": 77}
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	defer apiServer.Close()
+	minifluxServer := &MinifluxServer{client: client.NewClient(apiServer.URL, "test-api-key")}
+
+	call := func(arguments map[string]any) (*mcp.CallToolResult, subscriptionResult) {
+		t.Helper()
+		result, err := minifluxServer.Subscribe(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: arguments}})
+		if err != nil {
+			t.Fatalf("Subscribe returned error: %v", err)
+		}
+		subscription, _ := result.StructuredContent.(subscriptionResult)
+		return result, subscription
+	}
+
+	_, subscription := call(map[string]any{"url": "https://example.com", "category": "blogs"})
+	if subscription.Status != subscriptionCreated || subscription.FeedID != 77 || subscription.FeedURL != "https://example.com/atom.xml" {
+		t.Errorf("subscription = %+v, want the Atom feed subscribed", subscription)
+	}
+	if len(created) != 1 || created[0]["category_id"] != float64(4) {
+		t.Errorf("created feeds = %v, want one in category 4", created)
+	}
+
+	_, subscription = call(map[string]any{"url": "https://example.com", "pick": "https://example.com/feed"})
+	if subscription.Status != subscriptionCreated || subscription.FeedURL != "https://example.com/feed/" || created[1]["category_id"] != float64(0) || subscription.CategoryID != 0 {
+		t.Errorf("picked subscription = %+v, created %v, want the RSS feed without a category, leaving it to Miniflux", subscription, created)
+	}
+
+	subscribed = client.Feeds{{ID: 77, FeedURL: "https://example.com/atom.xml"}}
+	_, subscription = call(map[string]any{"url": "https://example.com"})
+	if subscription.Status != subscriptionExisting || subscription.FeedID != 77 || len(created) != 2 {
+		t.Errorf("repeated subscription = %+v, want the existing feed and nothing created", subscription)
+	}
+
+	discovered = client.Subscriptions{
+		{Title: "Posts", URL: "https://example.com/posts.xml", Type: "rss"},
+		{Title: "Links", URL: "https://example.com/links.xml", Type: "rss"},
+	}
+	_, subscription = call(map[string]any{"url": "https://example.com"})
+	if subscription.Status != subscriptionNeedsSelection || subscription.FeedID != 0 || len(subscription.Candidates) != 2 || len(created) != 2 {
+		t.Errorf("ambiguous subscription = %+v, want a selection request", subscription)
+	}
+
+	result, _ := call(map[string]any{"url": "https://example.com", "pick": "9"})
+	structured, _ := result.StructuredContent.(map[string]any)
+	if argErr, ok := structured["error"].(*argumentError); !result.IsError || !ok || argErr.Argument != "pick" || len(argErr.Accepted) != 2 {
+		t.Errorf("unknown pick = %#v, want a pick error listing the candidates", result)
+	}
+}
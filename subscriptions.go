@@ -0,0 +1,219 @@
+package main
+
+import (
+	"context"
+	"log"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"miniflux.app/v2/client"
+)
+
+// resourceFeedPrefix is the URI prefix of feeds matched by resourceFeedTemplate.
+const resourceFeedPrefix = "miniflux://feed/"
+
+// resourceSubscriptions records which sessions subscribed to which resource
+// URIs. It is safe for concurrent use.
+type resourceSubscriptions struct {
+	mu       sync.Mutex
+	sessions map[string]map[string]bool
+}
+
+func newResourceSubscriptions() *resourceSubscriptions {
+	return &resourceSubscriptions{sessions: map[string]map[string]bool{}}
+}
+
+func (r *resourceSubscriptions) subscribe(sessionID, uri string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.sessions[uri] == nil {
+		r.sessions[uri] = map[string]bool{}
+	}
+	r.sessions[uri][sessionID] = true
+}
+
+func (r *resourceSubscriptions) unsubscribe(sessionID, uri string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.sessions[uri], sessionID)
+	if len(r.sessions[uri]) == 0 {
+		delete(r.sessions, uri)
+	}
+}
+
+// removeSession drops every subscription of a session that has ended.
+func (r *resourceSubscriptions) removeSession(sessionID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for uri, sessions := range r.sessions {
+		delete(sessions, sessionID)
+		if len(sessions) == 0 {
+			delete(r.sessions, uri)
+		}
+	}
+}
+
+// uris returns the subscribed resource URIs in sorted order.
+func (r *resourceSubscriptions) uris() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Sorted(maps.Keys(r.sessions))
+}
+
+// subscribers returns the sessions subscribed to uri in sorted order.
+func (r *resourceSubscriptions) subscribers(uri string) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Sorted(maps.Keys(r.sessions[uri]))
+}
+
+// hooks keeps the subscriptions in sync with resources/subscribe and
+// resources/unsubscribe requests and with the sessions of the server.
+func (r *resourceSubscriptions) hooks() *server.Hooks {
+	hooks := &server.Hooks{}
+	hooks.AddAfterSubscribe(func(ctx context.Context, id any, message *mcp.SubscribeRequest, result *mcp.EmptyResult) {
+		if session := server.ClientSessionFromContext(ctx); session != nil {
+			r.subscribe(session.SessionID(), message.Params.URI)
+		}
+	})
+	hooks.AddAfterUnsubscribe(func(ctx context.Context, id any, message *mcp.UnsubscribeRequest, result *mcp.EmptyResult) {
+		if session := server.ClientSessionFromContext(ctx); session != nil {
+			r.unsubscribe(session.SessionID(), message.Params.URI)
+		}
+	})
+	hooks.AddOnUnregisterSession(func(ctx context.Context, session server.ClientSession) {
+		r.removeSession(session.SessionID())
+	})
+	return hooks
+}
+
+// resourcePoller polls Miniflux for changes to subscribed resources and
+// notifies the subscribed sessions with notifications/resources/updated.
+//
+// The first poll after a subscription records the current state; later polls
+// notify when the unread or read counters differ from it, or when entries of a
+// subscribed feed were added or changed since.
+type resourcePoller struct {
+	client        *client.Client
+	mcpServer     *server.MCPServer
+	subscriptions *resourceSubscriptions
+
+	counters  *feedCounters
+	feedMarks map[int64]time.Time
+}
+
+func newResourcePoller(minifluxServer *MinifluxServer, mcpServer *server.MCPServer) *resourcePoller {
+	return &resourcePoller{
+		client:        minifluxServer.client,
+		mcpServer:     mcpServer,
+		subscriptions: minifluxServer.subscriptions,
+		feedMarks:     map[int64]time.Time{},
+	}
+}
+
+// run polls every interval until ctx is done.
+func (p *resourcePoller) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.poll()
+		}
+	}
+}
+
+// poll checks every subscribed resource once.
+func (p *resourcePoller) poll() {
+	uris := p.subscriptions.uris()
+
+	if slices.Contains(uris, resourceCountersURI) {
+		p.pollCounters()
+	} else {
+		p.counters = nil
+	}
+
+	subscribedFeeds := map[int64]bool{}
+	for _, uri := range uris {
+		feedID, ok := feedResourceID(uri)
+		if !ok {
+			continue
+		}
+		subscribedFeeds[feedID] = true
+		p.pollFeed(uri, feedID)
+	}
+	for feedID := range p.feedMarks {
+		if !subscribedFeeds[feedID] {
+			delete(p.feedMarks, feedID)
+		}
+	}
+}
+
+func (p *resourcePoller) pollCounters() {
+	fetched, err := p.client.FetchCounters()
+	if err != nil {
+		log.Printf("Failed to poll feed counters: %v", err)
+		return
+	}
+
+	counters := newFeedCounters(fetched)
+	previous := p.counters
+	p.counters = &counters
+	if previous != nil && (!maps.Equal(previous.Unreads, counters.Unreads) || !maps.Equal(previous.Reads, counters.Reads)) {
+		p.notify(resourceCountersURI)
+	}
+}
+
+func (p *resourcePoller) pollFeed(uri string, feedID int64) {
+	mark, seen := p.feedMarks[feedID]
+	if !seen {
+		p.feedMarks[feedID] = time.Now()
+		return
+	}
+
+	// Miniflux compares whole seconds, so the most recently changed entry is
+	// compared with the mark again to report each change once.
+	changed, err := p.client.Entries(&client.Filter{
+		FeedID:       feedID,
+		ChangedAfter: mark.Unix(),
+		Order:        "changed_at",
+		Direction:    "desc",
+		Limit:        1,
+	})
+	if err != nil {
+		log.Printf("Failed to poll entries of feed %d: %v", feedID, err)
+		return
+	}
+	if len(changed.Entries) == 0 || !changed.Entries[0].ChangedAt.After(mark) {
+		return
+	}
+	p.feedMarks[feedID] = changed.Entries[0].ChangedAt
+	p.notify(uri)
+}
+
+func (p *resourcePoller) notify(uri string) {
+	for _, sessionID := range p.subscriptions.subscribers(uri) {
+		err := p.mcpServer.SendNotificationToSpecificClient(sessionID, mcp.MethodNotificationResourceUpdated, map[string]any{"uri": uri})
+		if err != nil {
+			log.Printf("Failed to notify session %s of %s update: %v", sessionID, uri, err)
+		}
+	}
+}
+
+// feedResourceID returns the feed ID of a URI matched by resourceFeedTemplate.
+func feedResourceID(uri string) (int64, bool) {
+	value, ok := strings.CutPrefix(uri, resourceFeedPrefix)
+	if !ok {
+		return 0, false
+	}
+	feedID, err := strconv.ParseInt(value, 10, 64)
+	return feedID, err == nil && feedID > 0
+}
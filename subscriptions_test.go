@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"miniflux.app/v2/client"
+)
+
+type testSession struct {
+	id            string
+	notifications chan mcp.JSONRPCNotification
+}
+
+var _ server.ClientSession = (*testSession)(nil)
+
+func (s *testSession) Initialize()                                         {}
+func (s *testSession) Initialized() bool                                   { return true }
+func (s *testSession) NotificationChannel() chan<- mcp.JSONRPCNotification { return s.notifications }
+func (s *testSession) SessionID() string                                   { return s.id }
+
+// updatedURIs drains the resource update notifications sent to the session.
+func (s *testSession) updatedURIs() []string {
+	var uris []string
+	for {
+		select {
+		case notification := <-s.notifications:
+			if notification.Method == mcp.MethodNotificationResourceUpdated {
+				uris = append(uris, notification.Params.AdditionalFields["uri"].(string))
+			}
+		default:
+			return uris
+		}
+	}
+}
+
+// fakeMinifluxChanges serves feed counters and the entries of feed 42, both
+// of which tests can change between polls.
+type fakeMinifluxChanges struct {
+	mu        sync.Mutex
+	unreads   map[int64]int
+	changedAt time.Time
+}
+
+func (f *fakeMinifluxChanges) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	switch r.URL.Path {
+	case "/v1/feeds/counters":
+		_ = json.NewEncoder(w).Encode(client.FeedCounters{ReadCounters: map[int64]int{}, UnreadCounters: f.unreads})
+	case "/v1/entries":
+		result := client.EntryResultSet{Entries: client.Entries{}}
+		changedAfter, _ := strconv.ParseInt(r.URL.Query().Get("changed_after"), 10, 64)
+		if r.URL.Query().Get("feed_id") == "42" && f.changedAt.Unix() > changedAfter {
+			result.Total = 1
+			result.Entries = client.Entries{{ID: 1, FeedID: 42, ChangedAt: f.changedAt}}
+		}
+		_ = json.NewEncoder(w).Encode(result)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (f *fakeMinifluxChanges) set(unreads map[int64]int, changedAt time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.unreads, f.changedAt = unreads, changedAt
+}
+
+func TestResourceSubscriptions(t *testing.T) {
+	api := &fakeMinifluxChanges{unreads: map[int64]int{42: 3}, changedAt: time.Now().Add(-time.Hour)}
+	apiServer := httptest.NewServer(api)
+	defer apiServer.Close()
+
+	minifluxServer := &MinifluxServer{
+		client:        client.NewClient(apiServer.URL, "test-api-key"),
+		subscriptions: newResourceSubscriptions(),
+	}
+	mcpServer := newMCPServer(minifluxServer)
+	session := &testSession{id: "session-1", notifications: make(chan mcp.JSONRPCNotification, 10)}
+	if err := mcpServer.RegisterSession(context.Background(), session); err != nil {
+		t.Fatalf("register session: %v", err)
+	}
+	sessionRequest := func(method, uri string) {
+		t.Helper()
+		message, _ := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": 1, "method": method, "params": map[string]any{"uri": uri}})
+		if _, ok := mcpServer.HandleMessage(mcpServer.WithContext(context.Background(), session), message).(mcp.JSONRPCResponse); !ok {
+			t.Fatalf("%s %s failed", method, uri)
+		}
+	}
+	sessionRequest("resources/subscribe", resourceCountersURI)
+	sessionRequest("resources/subscribe", "miniflux://feed/42")
+
+	poller := newResourcePoller(minifluxServer, mcpServer)
+	poller.poll()
+	if uris := session.updatedURIs(); len(uris) != 0 {
+		t.Fatalf("first poll notified %v, want nothing", uris)
+	}
+
+	poller.poll()
+	if uris := session.updatedURIs(); len(uris) != 0 {
+		t.Fatalf("poll without changes notified %v", uris)
+	}
+
+	api.set(map[int64]int{42: 4}, time.Now().Add(time.Second))
+	poller.poll()
+	if uris := session.updatedURIs(); len(uris) != 2 || uris[0] != resourceCountersURI || uris[1] != "miniflux://feed/42" {
+		t.Fatalf("poll after changes notified %v, want counters and feed 42", uris)
+	}
+	poller.poll()
+	if uris := session.updatedURIs(); len(uris) != 0 {
+		t.Fatalf("changes were notified again: %v", uris)
+	}
+
+	sessionRequest("resources/unsubscribe", resourceCountersURI)
+	api.set(map[int64]int{42: 5}, time.Now().Add(-time.Hour))
+	poller.poll()
+	if uris := session.updatedURIs(); len(uris) != 0 {
+		t.Fatalf("unsubscribed resource notified %v", uris)
+	}
+
+	mcpServer.UnregisterSession(context.Background(), session.id)
+	if uris := minifluxServer.subscriptions.uris(); len(uris) != 0 {
+		t.Errorf("subscriptions of an ended session remain: %v", uris)
+	}
+}
+
+func TestResourceSubscriptionsDisabled(t *testing.T) {
+	mcpServer := newMCPServer(&MinifluxServer{})
+	if _, ok := sendRequest(t, mcpServer, "resources/subscribe", map[string]any{"uri": resourceCountersURI}).(mcp.JSONRPCError); !ok {
+		t.Error("resources/subscribe succeeded without subscription support")
+	}
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// toolConfig controls which tools the server exposes and how they respond.
+type toolConfig struct {
+	// ReadOnly restricts the server to tools annotated as read-only, both
+	// when registering tools and when dispatching calls.
+	ReadOnly bool
+	// Enabled lists the tool groups, tool names or tool name patterns to
+	// expose. An empty list exposes every tool.
+	Enabled []string
+	// Disabled lists the tool groups, tool names or tool name patterns to
+	// hide. It takes precedence over Enabled.
+	Disabled []string
+	// MaxChars is the default response budget of entry tools, in characters.
+	// Zero means unlimited; callers can override it with max_chars.
+	MaxChars int
+}
+
+func loadToolConfig() (toolConfig, error) {
+	readOnly, err := envBool("MCP_READ_ONLY", false)
+	if err != nil {
+		return toolConfig{}, err
+	}
+
+	maxChars, err := envInt("MCP_MAX_CHARS", 0)
+	if err != nil {
+		return toolConfig{}, err
+	}
+
+	cfg := toolConfig{
+		ReadOnly: readOnly,
+		Enabled:  splitToolSelection(os.Getenv("MCP_TOOLS")),
+		Disabled: splitToolSelection(os.Getenv("MCP_TOOLS_DENY")),
+		MaxChars: maxChars,
+	}
+	if err := cfg.validate(); err != nil {
+		return toolConfig{}, err
+	}
+	return cfg, nil
+}
+
+// validate rejects a negative budget and selectors that are malformed or
+// select no tool, so a typo does not silently expose or hide the wrong tools.
+func (c toolConfig) validate() error {
+	if c.MaxChars < 0 {
+		return fmt.Errorf("the response budget cannot be negative, got %d", c.MaxChars)
+	}
+
+	definitions := registeredToolDefinitions()
+	for _, selector := range slices.Concat(c.Enabled, c.Disabled) {
+		if slices.Contains(toolGroups, selector) {
+			continue
+		}
+		if _, err := path.Match(selector, ""); err != nil {
+			return fmt.Errorf("invalid tool pattern %q: %w", selector, err)
+		}
+		matched := false
+		for name := range definitions {
+			if ok, _ := path.Match(selector, name); ok {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return fmt.Errorf("%q matches no tool group or tool name (groups: %s)", selector, strings.Join(toolGroups, ", "))
+		}
+	}
+	return nil
+}
+
+// allows reports whether the configuration exposes the tool.
+func (c toolConfig) allows(toolDef ToolDefinition) bool {
+	if c.ReadOnly && !toolDef.isReadOnly() {
+		return false
+	}
+	if len(c.Enabled) > 0 && !selectsTool(c.Enabled, toolDef) {
+		return false
+	}
+	return !selectsTool(c.Disabled, toolDef)
+}
+
+func selectsTool(selectors []string, toolDef ToolDefinition) bool {
+	for _, selector := range selectors {
+		if selector == toolDef.Group {
+			return true
+		}
+		if ok, _ := path.Match(selector, toolDef.Tool.Name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// splitToolSelection parses a comma-separated list of tool selectors.
+func splitToolSelection(value string) []string {
+	var selectors []string
+	for _, selector := range strings.Split(value, ",") {
+		if selector = strings.TrimSpace(selector); selector != "" {
+			selectors = append(selectors, selector)
+		}
+	}
+	return selectors
+}
+
+func envBool(name string, fallback bool) (bool, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback, nil
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%s must be a boolean, got %q", name, value)
+	}
+	return parsed, nil
+}
+
+func envInt(name string, fallback int) (int, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback, nil
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be an integer, got %q", name, value)
+	}
+	return parsed, nil
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLoadToolConfig(t *testing.T) {
+	t.Setenv("MCP_READ_ONLY", "")
+	cfg, err := loadToolConfig()
+	if err != nil {
+		t.Fatalf("loadToolConfig returned error: %v", err)
+	}
+	if cfg.ReadOnly {
+		t.Error("read-only mode is enabled by default")
+	}
+
+	t.Setenv("MCP_READ_ONLY", "true")
+	cfg, err = loadToolConfig()
+	if err != nil {
+		t.Fatalf("loadToolConfig returned error: %v", err)
+	}
+	if !cfg.ReadOnly {
+		t.Error("MCP_READ_ONLY=true did not enable read-only mode")
+	}
+
+	t.Setenv("MCP_READ_ONLY", "sometimes")
+	if _, err := loadToolConfig(); err == nil {
+		t.Error("loadToolConfig accepted an invalid MCP_READ_ONLY")
+	}
+}
+
+func TestToolConfigSelection(t *testing.T) {
+	testCases := []struct {
+		name    string
+		cfg     toolConfig
+		exposed []string
+		hidden  []string
+	}{
+		{
+			name:    "everything by default",
+			cfg:     toolConfig{},
+			exposed: []string{"get_feeds", "delete_user", "get_enclosure"},
+		},
+		{
+			name:    "groups",
+			cfg:     toolConfig{Enabled: []string{"entries", "feeds"}},
+			exposed: []string{"get_entries", "update_entry_status", "get_feeds", "delete_feed"},
+			hidden:  []string{"get_categories", "get_users", "get_version", "get_api_keys", "get_icon"},
+		},
+		{
+			name:    "names and patterns",
+			cfg:     toolConfig{Enabled: []string{"get_*", "healthcheck"}},
+			exposed: []string{"get_feeds", "get_me", "healthcheck"},
+			hidden:  []string{"create_feed", "export"},
+		},
+		{
+			name:    "deny patterns take precedence",
+			cfg:     toolConfig{Enabled: []string{"feeds", "users"}, Disabled: []string{"delete_*", "create_user"}},
+			exposed: []string{"get_feeds", "create_feed", "get_users"},
+			hidden:  []string{"delete_feed", "delete_user", "create_user", "get_entries"},
+		},
+		{
+			name:    "deny groups",
+			cfg:     toolConfig{Disabled: []string{"users", "api_keys"}},
+			exposed: []string{"get_feeds", "get_entries"},
+			hidden:  []string{"get_me", "create_user", "get_api_keys"},
+		},
+		{
+			name:    "combined with read-only mode",
+			cfg:     toolConfig{ReadOnly: true, Enabled: []string{"feeds"}},
+			exposed: []string{"get_feeds", "get_feed_entries"},
+			hidden:  []string{"create_feed", "refresh_feed", "get_entries"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if err := testCase.cfg.validate(); err != nil {
+				t.Fatalf("validate returned error: %v", err)
+			}
+			registered := newMCPServer(&MinifluxServer{tools: testCase.cfg}).ListTools()
+			for _, name := range testCase.exposed {
+				if _, ok := registered[name]; !ok {
+					t.Errorf("%s is not registered", name)
+				}
+			}
+			for _, name := range testCase.hidden {
+				if _, ok := registered[name]; ok {
+					t.Errorf("%s is registered", name)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadToolConfigSelection(t *testing.T) {
+	t.Setenv("MCP_READ_ONLY", "")
+	t.Setenv("MCP_TOOLS", " entries, feeds ,,get_me")
+	t.Setenv("MCP_TOOLS_DENY", "delete_*")
+	cfg, err := loadToolConfig()
+	if err != nil {
+		t.Fatalf("loadToolConfig returned error: %v", err)
+	}
+	if !reflect.DeepEqual(cfg.Enabled, []string{"entries", "feeds", "get_me"}) {
+		t.Errorf("enabled = %#v", cfg.Enabled)
+	}
+	if !reflect.DeepEqual(cfg.Disabled, []string{"delete_*"}) {
+		t.Errorf("disabled = %#v", cfg.Disabled)
+	}
+
+	for _, selection := range []string{"entrys", "get_feedz", "[get"} {
+		t.Setenv("MCP_TOOLS", selection)
+		if _, err := loadToolConfig(); err == nil {
+			t.Errorf("loadToolConfig accepted MCP_TOOLS=%s", selection)
+		}
+	}
+}
+
+func TestDisabledToolsAreRefusedAtDispatch(t *testing.T) {
+	mcpServer := newMCPServer(&MinifluxServer{tools: toolConfig{Disabled: []string{"users"}}})
+	for _, toolDef := range (&MinifluxServer{}).toolDefinitions() {
+		if toolDef.Tool.Name == "get_me" {
+			mcpServer.AddTool(toolDef.Tool, toolDef.Handler)
+		}
+	}
+
+	if result := callTool(t, mcpServer, "get_me", map[string]any{}); !result.IsError {
+		t.Error("get_me was dispatched although the users group is disabled")
+	}
+}
+
+func TestLoadToolConfigMaxChars(t *testing.T) {
+	t.Setenv("MCP_READ_ONLY", "")
+	t.Setenv("MCP_TOOLS", "")
+	t.Setenv("MCP_TOOLS_DENY", "")
+
+	t.Setenv("MCP_MAX_CHARS", "20000")
+	cfg, err := loadToolConfig()
+	if err != nil || cfg.MaxChars != 20000 {
+		t.Errorf("loadToolConfig = %+v, %v, want max chars 20000", cfg, err)
+	}
+
+	for _, value := range []string{"lots", "-1"} {
+		t.Setenv("MCP_MAX_CHARS", value)
+		if _, err := loadToolConfig(); err == nil {
+			t.Errorf("loadToolConfig accepted MCP_MAX_CHARS=%s", value)
+		}
+	}
+}
@@ -1,515 +1,411 @@
 package main
 
 import (
+	"context"
+	"fmt"
+	"sync"
+
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"miniflux.app/v2/client"
+)
+
+// Tool groups, used to select related tools through configuration.
+const (
+	toolGroupFeeds      = "feeds"
+	toolGroupEntries    = "entries"
+	toolGroupCategories = "categories"
+	toolGroupUsers      = "users"
+	toolGroupSystem     = "system"
+	toolGroupAPIKeys    = "api_keys"
+	toolGroupIcons      = "icons"
 )
 
+var toolGroups = []string{
+	toolGroupFeeds,
+	toolGroupEntries,
+	toolGroupCategories,
+	toolGroupUsers,
+	toolGroupSystem,
+	toolGroupAPIKeys,
+	toolGroupIcons,
+}
+
+// ToolDefinition pairs a tool with its handler. Group is one of the tool
+// groups. Args is a zero value of the struct the handler binds its arguments
+// into; the tool's input schema is generated from it. Output, when set,
+// declares the output schema of the structured content the handler returns.
 type ToolDefinition struct {
 	Tool    mcp.Tool
+	Group   string
+	Args    any
+	Output  mcp.ToolOption
 	Handler server.ToolHandlerFunc
 }
 
+// Tool annotation helpers. The hints follow the MCP definitions: read-only
+// tools are never destructive and always idempotent, destructive tools remove
+// data or state that cannot be recovered, and open-world tools make Miniflux
+// reach out to arbitrary external sites.
+
+func readOnlyTool(title string) mcp.ToolAnnotation {
+	return mcp.ToolAnnotation{
+		Title:           title,
+		ReadOnlyHint:    mcp.ToBoolPtr(true),
+		DestructiveHint: mcp.ToBoolPtr(false),
+		IdempotentHint:  mcp.ToBoolPtr(true),
+		OpenWorldHint:   mcp.ToBoolPtr(false),
+	}
+}
+
+func updateTool(title string, idempotent bool) mcp.ToolAnnotation {
+	return mcp.ToolAnnotation{
+		Title:           title,
+		ReadOnlyHint:    mcp.ToBoolPtr(false),
+		DestructiveHint: mcp.ToBoolPtr(false),
+		IdempotentHint:  mcp.ToBoolPtr(idempotent),
+		OpenWorldHint:   mcp.ToBoolPtr(false),
+	}
+}
+
+func destructiveTool(title string, idempotent bool) mcp.ToolAnnotation {
+	annotation := updateTool(title, idempotent)
+	annotation.DestructiveHint = mcp.ToBoolPtr(true)
+	return annotation
+}
+
+func openWorld(annotation mcp.ToolAnnotation) mcp.ToolAnnotation {
+	annotation.OpenWorldHint = mcp.ToBoolPtr(true)
+	return annotation
+}
+
+// isReadOnly reports whether the tool is annotated as never modifying
+// Miniflux.
+func (d ToolDefinition) isReadOnly() bool {
+	hint := d.Tool.Annotations.ReadOnlyHint
+	return hint != nil && *hint
+}
+
+// registeredToolDefinitions indexes the tool definitions by name. Names,
+// groups and annotations do not depend on the server instance, so the index
+// is built once and only used to look up that metadata.
+var registeredToolDefinitions = sync.OnceValue(func() map[string]ToolDefinition {
+	definitions := map[string]ToolDefinition{}
+	for _, toolDef := range (&MinifluxServer{}).toolDefinitions() {
+		definitions[toolDef.Tool.Name] = toolDef
+	}
+	return definitions
+})
+
+// toolAvailable reports whether the named tool is exposed by the server.
+func (s *MinifluxServer) toolAvailable(name string) bool {
+	toolDef, known := registeredToolDefinitions()[name]
+	return known && s.tools.allows(toolDef)
+}
+
+// groupAvailable reports whether the server exposes any tool of the group.
+func (s *MinifluxServer) groupAvailable(group string) bool {
+	for _, toolDef := range registeredToolDefinitions() {
+		if toolDef.Group == group && s.tools.allows(toolDef) {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *MinifluxServer) RegisterAllTools(mcpServer *server.MCPServer) {
+	for _, toolDef := range s.toolDefinitions() {
+		if !s.tools.allows(toolDef) {
+			continue
+		}
+		mcpServer.AddTool(toolDef.Tool, toolDef.Handler)
+	}
+}
+
+// guardDisabledTools is a tool handler middleware that refuses every tool the
+// tool configuration disables, including mutating tools in read-only mode. It
+// backs up the filtering in RegisterAllTools, so a tool registered by any
+// other path still cannot reach Miniflux.
+func (s *MinifluxServer) guardDisabledTools(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		toolDef, known := registeredToolDefinitions()[request.Params.Name]
+		if s.tools.ReadOnly && (!known || !toolDef.isReadOnly()) {
+			return mcp.NewToolResultError(fmt.Sprintf("Tool %s is not available: the server is in read-only mode", request.Params.Name)), nil
+		}
+		if known && !s.tools.allows(toolDef) {
+			return mcp.NewToolResultError(fmt.Sprintf("Tool %s is not available: it is disabled by the server's tool selection", request.Params.Name)), nil
+		}
+		return next(ctx, request)
+	}
+}
+
+func (s *MinifluxServer) toolDefinitions() []ToolDefinition {
 	tools := []ToolDefinition{
 		// Feed Operations
 		{
 			Tool: mcp.Tool{
 				Name:        "get_feeds",
 				Description: "Get all RSS/Atom feeds from Miniflux",
-				InputSchema: mcp.ToolInputSchema{
-					Type:       "object",
-					Properties: map[string]interface{}{},
-				},
+				Annotations: readOnlyTool("Get Feeds"),
 			},
+			Group:   toolGroupFeeds,
+			Args:    feedsArgs{},
+			Output:  projectableOutputSchema[feedList]("feeds"),
 			Handler: s.GetFeeds,
 		},
 		{
 			Tool: mcp.Tool{
 				Name:        "get_feed",
 				Description: "Get a specific feed by ID",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: map[string]interface{}{
-						"feed_id": map[string]interface{}{
-							"type":        "number",
-							"description": "The ID of the feed to retrieve",
-						},
-					},
-					Required: []string{"feed_id"},
-				},
+				Annotations: readOnlyTool("Get Feed"),
 			},
+			Group:   toolGroupFeeds,
+			Args:    feedArgs{},
+			Output:  mcp.WithOutputSchema[client.Feed](),
 			Handler: s.GetFeed,
 		},
 		{
 			Tool: mcp.Tool{
 				Name:        "create_feed",
 				Description: "Add a new RSS/Atom feed to Miniflux",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: map[string]interface{}{
-						"feed_url": map[string]interface{}{
-							"type":        "string",
-							"description": "The URL of the RSS/Atom feed to add",
-						},
-						"category_id": map[string]interface{}{
-							"type":        "number",
-							"description": "The category ID to assign the feed to (default: 1)",
-						},
-						"crawler": map[string]interface{}{
-							"type":        "boolean",
-							"description": "Enable web scraper for full content",
-						},
-						"user_agent": map[string]interface{}{
-							"type":        "string",
-							"description": "Custom user agent for feed fetching",
-						},
-						"username": map[string]interface{}{
-							"type":        "string",
-							"description": "Username for HTTP basic authentication",
-						},
-						"password": map[string]interface{}{
-							"type":        "string",
-							"description": "Password for HTTP basic authentication",
-						},
-					},
-					Required: []string{"feed_url"},
-				},
+				Annotations: openWorld(updateTool("Create Feed", false)),
 			},
+			Group:   toolGroupFeeds,
+			Args:    createFeedArgs{},
 			Handler: s.CreateFeed,
 		},
+		{
+			Tool: mcp.Tool{
+				Name:        "subscribe",
+				Description: "Subscribe to a website or feed URL: discovers its feeds, ranks them (same host first, then Atom, RSS and JSON Feed) and subscribes to the best one unless it is already subscribed; when several feeds rank equally, lists them to pick from",
+				Annotations: openWorld(updateTool("Subscribe", true)),
+			},
+			Group:   toolGroupFeeds,
+			Args:    subscribeArgs{},
+			Output:  mcp.WithOutputSchema[subscriptionResult](),
+			Handler: s.Subscribe,
+		},
 		{
 			Tool: mcp.Tool{
 				Name:        "update_feed",
 				Description: "Update an existing feed",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: map[string]interface{}{
-						"feed_id": map[string]interface{}{
-							"type":        "number",
-							"description": "The ID of the feed to update",
-						},
-						"feed_url": map[string]interface{}{
-							"type":        "string",
-							"description": "New RSS/Atom feed URL",
-						},
-						"site_url": map[string]interface{}{
-							"type":        "string",
-							"description": "New website URL",
-						},
-						"title": map[string]interface{}{
-							"type":        "string",
-							"description": "New feed title",
-						},
-						"category_id": map[string]interface{}{
-							"type":        "number",
-							"description": "Category ID to move the feed to",
-						},
-						"scraper_rules": map[string]interface{}{
-							"type":        "string",
-							"description": "CSS selectors for scraping article content",
-						},
-						"rewrite_rules": map[string]interface{}{
-							"type":        "string",
-							"description": "Content rewrite rules",
-						},
-						"urlrewrite_rules": map[string]interface{}{
-							"type":        "string",
-							"description": "URL rewrite rules",
-						},
-						"blocklist_rules": map[string]interface{}{
-							"type":        "string",
-							"description": "Entry blocklist rules",
-						},
-						"keeplist_rules": map[string]interface{}{
-							"type":        "string",
-							"description": "Entry keeplist rules",
-						},
-						"block_filter_entry_rules": map[string]interface{}{
-							"type":        "string",
-							"description": "Entry block filter rules",
-						},
-						"keep_filter_entry_rules": map[string]interface{}{
-							"type":        "string",
-							"description": "Entry keep filter rules",
-						},
-						"crawler": map[string]interface{}{
-							"type":        "boolean",
-							"description": "Enable or disable full-content scraping",
-						},
-						"user_agent": map[string]interface{}{
-							"type":        "string",
-							"description": "Custom user agent for feed fetching",
-						},
-						"cookie": map[string]interface{}{
-							"type":        "string",
-							"description": "Cookie header for feed fetching",
-						},
-						"username": map[string]interface{}{
-							"type":        "string",
-							"description": "Username for HTTP basic authentication",
-						},
-						"password": map[string]interface{}{
-							"type":        "string",
-							"description": "Password for HTTP basic authentication",
-						},
-						"disabled": map[string]interface{}{
-							"type":        "boolean",
-							"description": "Enable or disable feed fetching",
-						},
-						"ignore_http_cache": map[string]interface{}{
-							"type":        "boolean",
-							"description": "Ignore HTTP cache headers",
-						},
-						"allow_self_signed_certificates": map[string]interface{}{
-							"type":        "boolean",
-							"description": "Allow self-signed TLS certificates",
-						},
-						"fetch_via_proxy": map[string]interface{}{
-							"type":        "boolean",
-							"description": "Fetch the feed through the configured proxy",
-						},
-						"hide_globally": map[string]interface{}{
-							"type":        "boolean",
-							"description": "Hide feed entries from the global list",
-						},
-						"disable_http2": map[string]interface{}{
-							"type":        "boolean",
-							"description": "Disable HTTP/2 when fetching the feed",
-						},
-						"proxy_url": map[string]interface{}{
-							"type":        "string",
-							"description": "Proxy URL used to fetch the feed",
-						},
-					},
-					Required: []string{"feed_id"},
-				},
+				Annotations: updateTool("Update Feed", true),
 			},
+			Group:   toolGroupFeeds,
+			Args:    updateFeedArgs{},
+			Output:  mcp.WithOutputSchema[client.Feed](),
 			Handler: s.UpdateFeed,
 		},
 		{
 			Tool: mcp.Tool{
 				Name:        "delete_feed",
 				Description: "Delete a specific feed",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: map[string]interface{}{
-						"feed_id": map[string]interface{}{
-							"type":        "number",
-							"description": "The ID of the feed to delete",
-						},
-					},
-					Required: []string{"feed_id"},
-				},
+				Annotations: destructiveTool("Delete Feed", true),
 			},
+			Group:   toolGroupFeeds,
+			Args:    feedArgs{},
 			Handler: s.DeleteFeed,
 		},
 		{
 			Tool: mcp.Tool{
 				Name:        "refresh_feed",
 				Description: "Manually refresh a specific feed",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: map[string]interface{}{
-						"feed_id": map[string]interface{}{
-							"type":        "number",
-							"description": "The ID of the feed to refresh",
-						},
-					},
-					Required: []string{"feed_id"},
-				},
+				Annotations: openWorld(updateTool("Refresh Feed", true)),
 			},
+			Group:   toolGroupFeeds,
+			Args:    feedArgs{},
 			Handler: s.RefreshFeed,
 		},
 		{
 			Tool: mcp.Tool{
 				Name:        "refresh_all_feeds",
 				Description: "Refresh all feeds",
-				InputSchema: mcp.ToolInputSchema{
-					Type:       "object",
-					Properties: map[string]interface{}{},
-				},
+				Annotations: openWorld(updateTool("Refresh All Feeds", true)),
 			},
+			Group:   toolGroupFeeds,
+			Args:    noArgs{},
 			Handler: s.RefreshAllFeeds,
 		},
 		{
 			Tool: mcp.Tool{
 				Name:        "get_feed_entries",
 				Description: "Get entries from a specific feed",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: map[string]interface{}{
-						"feed_id": map[string]interface{}{
-							"type":        "number",
-							"description": "The ID of the feed",
-						},
-						"status": map[string]interface{}{
-							"type":        "string",
-							"description": "Filter by entry status (read, unread, removed)",
-						},
-						"limit": map[string]interface{}{
-							"type":        "number",
-							"description": "Limit the number of entries returned",
-						},
-						"offset": map[string]interface{}{
-							"type":        "number",
-							"description": "Offset for pagination",
-						},
-					},
-					Required: []string{"feed_id"},
-				},
+				Annotations: readOnlyTool("Get Feed Entries"),
 			},
+			Group:   toolGroupFeeds,
+			Args:    feedEntriesArgs{},
+			Output:  projectableOutputSchema[entryPage]("entries"),
 			Handler: s.GetFeedEntries,
 		},
 		{
 			Tool: mcp.Tool{
 				Name:        "get_feed_entry",
 				Description: "Get a specific entry from a feed",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: map[string]interface{}{
-						"feed_id": map[string]interface{}{
-							"type":        "number",
-							"description": "The ID of the feed",
-						},
-						"entry_id": map[string]interface{}{
-							"type":        "number",
-							"description": "The ID of the entry",
-						},
-					},
-					Required: []string{"feed_id", "entry_id"},
-				},
+				Annotations: readOnlyTool("Get Feed Entry"),
 			},
+			Group:   toolGroupFeeds,
+			Args:    feedEntryArgs{},
+			Output:  mcp.WithOutputSchema[entryDocument](),
 			Handler: s.GetFeedEntry,
 		},
 		{
 			Tool: mcp.Tool{
 				Name:        "get_feed_icon",
 				Description: "Get the icon of a specific feed",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: map[string]interface{}{
-						"feed_id": map[string]interface{}{
-							"type":        "number",
-							"description": "The ID of the feed",
-						},
-					},
-					Required: []string{"feed_id"},
-				},
+				Annotations: readOnlyTool("Get Feed Icon"),
 			},
+			Group:   toolGroupFeeds,
+			Args:    feedArgs{},
 			Handler: s.GetFeedIcon,
 		},
 		{
 			Tool: mcp.Tool{
 				Name:        "mark_feed_as_read",
 				Description: "Mark all entries in a feed as read",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: map[string]interface{}{
-						"feed_id": map[string]interface{}{
-							"type":        "number",
-							"description": "The ID of the feed",
-						},
-					},
-					Required: []string{"feed_id"},
-				},
+				Annotations: destructiveTool("Mark Feed as Read", true),
 			},
+			Group:   toolGroupFeeds,
+			Args:    feedArgs{},
 			Handler: s.MarkFeedAsRead,
 		},
+		{
+			Tool: mcp.Tool{
+				Name:        "find_duplicate_feeds",
+				Description: "Find feeds subscribed more than once, such as under http and https, with and without a trailing slash or through a feed proxy like FeedBurner; each group lists why its feeds look alike and which one to keep",
+				Annotations: readOnlyTool("Find Duplicate Feeds"),
+			},
+			Group:   toolGroupFeeds,
+			Args:    noArgs{},
+			Output:  mcp.WithOutputSchema[duplicateFeedReport](),
+			Handler: s.FindDuplicateFeeds,
+		},
+		{
+			Tool: mcp.Tool{
+				Name:        "dedupe_feeds",
+				Description: "Delete the duplicate feeds found by find_duplicate_feeds, keeping one feed per group and starring its copies of the starred entries of the deleted feeds; nothing is deleted unless confirm is true",
+				Annotations: destructiveTool("Dedupe Feeds", true),
+			},
+			Group:   toolGroupFeeds,
+			Args:    dedupeFeedsArgs{},
+			Output:  mcp.WithOutputSchema[feedDedupe](),
+			Handler: s.DedupeFeeds,
+		},
+		{
+			Tool: mcp.Tool{
+				Name:        "feed_health",
+				Description: "Classify feeds as erroring, stale, disabled, never checked or overdue for a check, with counts per category and suggested actions such as refresh, rediscover or disable; only feeds with problems are listed unless include_healthy is true",
+				Annotations: readOnlyTool("Feed Health"),
+			},
+			Group:   toolGroupFeeds,
+			Args:    feedHealthArgs{},
+			Output:  mcp.WithOutputSchema[feedHealthReport](),
+			Handler: s.FeedHealth,
+		},
 
 		// Entry Operations
 		{
 			Tool: mcp.Tool{
 				Name:        "get_entries",
 				Description: "Get entries (articles) from Miniflux with optional filtering",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: map[string]interface{}{
-						"status": map[string]interface{}{
-							"type":        "string",
-							"description": "Filter by entry status (read, unread, removed)",
-							"enum":        []string{"read", "unread", "removed"},
-						},
-						"statuses": map[string]interface{}{
-							"type":        "array",
-							"description": "Filter by multiple entry statuses; takes precedence over status",
-							"items": map[string]interface{}{
-								"type": "string",
-								"enum": []string{"read", "unread", "removed"},
-							},
-						},
-						"feed_id": map[string]interface{}{
-							"type":        "number",
-							"description": "Filter by specific feed ID",
-						},
-						"category_id": map[string]interface{}{
-							"type":        "number",
-							"description": "Filter by specific category ID",
-						},
-						"limit": map[string]interface{}{
-							"type":        "number",
-							"description": "Limit the number of entries returned",
-						},
-						"offset": map[string]interface{}{
-							"type":        "number",
-							"description": "Offset for pagination",
-						},
-						"published_after": map[string]interface{}{
-							"type":        "number",
-							"description": "Return entries published after this Unix timestamp",
-						},
-						"published_before": map[string]interface{}{
-							"type":        "number",
-							"description": "Return entries published before this Unix timestamp",
-						},
-						"changed_after": map[string]interface{}{
-							"type":        "number",
-							"description": "Return entries changed after this Unix timestamp",
-						},
-						"changed_before": map[string]interface{}{
-							"type":        "number",
-							"description": "Return entries changed before this Unix timestamp",
-						},
-						"before_entry_id": map[string]interface{}{
-							"type":        "number",
-							"description": "Return entries with an ID lower than this value",
-						},
-						"after_entry_id": map[string]interface{}{
-							"type":        "number",
-							"description": "Return entries with an ID greater than this value",
-						},
-						"search": map[string]interface{}{
-							"type":        "string",
-							"description": "Search entry title and content",
-						},
-						"starred": map[string]interface{}{
-							"type":        "boolean",
-							"description": "Filter by starred state",
-						},
-						"order": map[string]interface{}{
-							"type":        "string",
-							"description": "Field used to sort entries",
-							"enum":        []string{"id", "status", "changed_at", "published_at", "created_at", "category_title", "category_id", "title", "author"},
-						},
-						"direction": map[string]interface{}{
-							"type":        "string",
-							"description": "Sort direction",
-							"enum":        []string{"asc", "desc"},
-						},
-						"globally_visible": map[string]interface{}{
-							"type":        "boolean",
-							"description": "Restrict results to globally visible entries when true",
-						},
-					},
-				},
+				Annotations: readOnlyTool("Get Entries"),
 			},
+			Group:   toolGroupEntries,
+			Args:    entriesArgs{},
+			Output:  projectableOutputSchema[entryPage]("entries"),
 			Handler: s.GetEntries,
 		},
 		{
 			Tool: mcp.Tool{
 				Name:        "get_entry",
 				Description: "Get a specific entry by ID",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: map[string]interface{}{
-						"entry_id": map[string]interface{}{
-							"type":        "number",
-							"description": "The ID of the entry to retrieve",
-						},
-					},
-					Required: []string{"entry_id"},
-				},
+				Annotations: readOnlyTool("Get Entry"),
 			},
+			Group:   toolGroupEntries,
+			Args:    getEntryArgs{},
+			Output:  mcp.WithOutputSchema[entryDocument](),
 			Handler: s.GetEntry,
 		},
 		{
 			Tool: mcp.Tool{
 				Name:        "update_entry_status",
 				Description: "Update the status of an entry (mark as read/unread/removed)",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: map[string]interface{}{
-						"entry_id": map[string]interface{}{
-							"type":        "number",
-							"description": "The ID of the entry to update",
-						},
-						"status": map[string]interface{}{
-							"type":        "string",
-							"description": "New status for the entry (read, unread, removed)",
-							"enum":        []string{"read", "unread", "removed"},
-						},
-					},
-					Required: []string{"entry_id", "status"},
-				},
+				Annotations: destructiveTool("Update Entry Status", true),
 			},
+			Group:   toolGroupEntries,
+			Args:    updateEntryStatusArgs{},
 			Handler: s.UpdateEntryStatus,
 		},
+		{
+			Tool: mcp.Tool{
+				Name:        "update_entries_status",
+				Description: "Update the status of many entries at once, selected by ID or with the same filters as get_entries; the entries are updated in chunks and the result of each chunk is reported",
+				Annotations: destructiveTool("Update Entries Status", true),
+			},
+			Group:   toolGroupEntries,
+			Args:    updateEntriesStatusArgs{},
+			Output:  mcp.WithOutputSchema[entryStatusUpdate](),
+			Handler: s.UpdateEntriesStatus,
+		},
+		{
+			Tool: mcp.Tool{
+				Name:        "update_entry",
+				Description: "Update the title or content of an entry, for example to fix a broken title or replace truncated content with the original",
+				Annotations: updateTool("Update Entry", true),
+			},
+			Group:   toolGroupEntries,
+			Args:    updateEntryArgs{},
+			Output:  mcp.WithOutputSchema[entryDocument](),
+			Handler: s.UpdateEntry,
+		},
 		{
 			Tool: mcp.Tool{
 				Name:        "toggle_starred",
 				Description: "Toggle starred status of an entry",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: map[string]interface{}{
-						"entry_id": map[string]interface{}{
-							"type":        "number",
-							"description": "The ID of the entry",
-						},
-					},
-					Required: []string{"entry_id"},
-				},
+				Annotations: updateTool("Toggle Starred", false),
 			},
+			Group:   toolGroupEntries,
+			Args:    entryArgs{},
 			Handler: s.ToggleStarred,
 		},
+		{
+			Tool: mcp.Tool{
+				Name:        "set_starred",
+				Description: "Star or unstar entries; entries already in the requested state are left unchanged, so retrying is safe",
+				Annotations: updateTool("Set Starred", true),
+			},
+			Group:   toolGroupEntries,
+			Args:    setStarredArgs{},
+			Output:  mcp.WithOutputSchema[starredUpdate](),
+			Handler: s.SetStarred,
+		},
 		{
 			Tool: mcp.Tool{
 				Name:        "save_entry",
 				Description: "Save an entry",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: map[string]interface{}{
-						"entry_id": map[string]interface{}{
-							"type":        "number",
-							"description": "The ID of the entry",
-						},
-					},
-					Required: []string{"entry_id"},
-				},
+				Annotations: openWorld(updateTool("Save Entry", false)),
 			},
+			Group:   toolGroupEntries,
+			Args:    entryArgs{},
 			Handler: s.SaveEntry,
 		},
 		{
 			Tool: mcp.Tool{
 				Name:        "fetch_original_content",
 				Description: "Fetch the original content of an entry",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: map[string]interface{}{
-						"entry_id": map[string]interface{}{
-							"type":        "number",
-							"description": "The ID of the entry",
-						},
-					},
-					Required: []string{"entry_id"},
-				},
+				Annotations: openWorld(readOnlyTool("Fetch Original Content")),
 			},
+			Group:   toolGroupEntries,
+			Args:    entryArgs{},
 			Handler: s.FetchEntryOriginalContent,
 		},
 		{
 			Tool: mcp.Tool{
 				Name:        "mark_all_as_read",
 				Description: "Mark all entries as read for a user",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: map[string]interface{}{
-						"user_id": map[string]interface{}{
-							"type":        "number",
-							"description": "The ID of the user",
-						},
-					},
-					Required: []string{"user_id"},
-				},
+				Annotations: destructiveTool("Mark All as Read", true),
 			},
+			Group:   toolGroupEntries,
+			Args:    userArgs{},
 			Handler: s.MarkAllAsRead,
 		},
 
@@ -518,163 +414,107 @@ func (s *MinifluxServer) RegisterAllTools(mcpServer *server.MCPServer) {
 			Tool: mcp.Tool{
 				Name:        "get_categories",
 				Description: "Get all feed categories from Miniflux",
-				InputSchema: mcp.ToolInputSchema{
-					Type:       "object",
-					Properties: map[string]interface{}{},
-				},
+				Annotations: readOnlyTool("Get Categories"),
 			},
+			Group:   toolGroupCategories,
+			Args:    noArgs{},
+			Output:  mcp.WithOutputSchema[categoryList](),
 			Handler: s.GetCategories,
 		},
 		{
 			Tool: mcp.Tool{
 				Name:        "create_category",
 				Description: "Create a new category",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: map[string]interface{}{
-						"title": map[string]interface{}{
-							"type":        "string",
-							"description": "The title of the category",
-						},
-					},
-					Required: []string{"title"},
-				},
+				Annotations: updateTool("Create Category", false),
 			},
+			Group:   toolGroupCategories,
+			Args:    createCategoryArgs{},
+			Output:  mcp.WithOutputSchema[client.Category](),
 			Handler: s.CreateCategory,
 		},
 		{
 			Tool: mcp.Tool{
 				Name:        "update_category",
-				Description: "Update a category title",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: map[string]interface{}{
-						"category_id": map[string]interface{}{
-							"type":        "number",
-							"description": "The ID of the category",
-						},
-						"title": map[string]interface{}{
-							"type":        "string",
-							"description": "The new title of the category",
-						},
-					},
-					Required: []string{"category_id", "title"},
-				},
+				Description: "Update the title or options of a category",
+				Annotations: updateTool("Update Category", true),
 			},
+			Group:   toolGroupCategories,
+			Args:    updateCategoryArgs{},
+			Output:  mcp.WithOutputSchema[client.Category](),
 			Handler: s.UpdateCategory,
 		},
 		{
 			Tool: mcp.Tool{
 				Name:        "delete_category",
 				Description: "Delete a category",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: map[string]interface{}{
-						"category_id": map[string]interface{}{
-							"type":        "number",
-							"description": "The ID of the category",
-						},
-					},
-					Required: []string{"category_id"},
-				},
+				Annotations: destructiveTool("Delete Category", true),
 			},
+			Group:   toolGroupCategories,
+			Args:    categoryArgs{},
 			Handler: s.DeleteCategory,
 		},
+		{
+			Tool: mcp.Tool{
+				Name:        "merge_categories",
+				Description: "Move every feed of the source categories to the target category, then delete the emptied source categories; each move is reported",
+				Annotations: destructiveTool("Merge Categories", false),
+			},
+			Group:   toolGroupCategories,
+			Args:    mergeCategoriesArgs{},
+			Output:  mcp.WithOutputSchema[categoryMerge](),
+			Handler: s.MergeCategories,
+		},
 		{
 			Tool: mcp.Tool{
 				Name:        "get_category_feeds",
 				Description: "Get all feeds in a specific category",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: map[string]interface{}{
-						"category_id": map[string]interface{}{
-							"type":        "number",
-							"description": "The ID of the category",
-						},
-					},
-					Required: []string{"category_id"},
-				},
+				Annotations: readOnlyTool("Get Category Feeds"),
 			},
+			Group:   toolGroupCategories,
+			Args:    categoryFeedsArgs{},
+			Output:  projectableOutputSchema[feedList]("feeds"),
 			Handler: s.GetCategoryFeeds,
 		},
 		{
 			Tool: mcp.Tool{
 				Name:        "get_category_entries",
 				Description: "Get all entries in a specific category",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: map[string]interface{}{
-						"category_id": map[string]interface{}{
-							"type":        "number",
-							"description": "The ID of the category",
-						},
-						"status": map[string]interface{}{
-							"type":        "string",
-							"description": "Filter by entry status (read, unread, removed)",
-						},
-						"limit": map[string]interface{}{
-							"type":        "number",
-							"description": "Limit the number of entries returned",
-						},
-					},
-					Required: []string{"category_id"},
-				},
+				Annotations: readOnlyTool("Get Category Entries"),
 			},
+			Group:   toolGroupCategories,
+			Args:    categoryEntriesArgs{},
+			Output:  projectableOutputSchema[entryPage]("entries"),
 			Handler: s.GetCategoryEntries,
 		},
 		{
 			Tool: mcp.Tool{
 				Name:        "get_category_entry",
 				Description: "Get a specific entry from a category",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: map[string]interface{}{
-						"category_id": map[string]interface{}{
-							"type":        "number",
-							"description": "The ID of the category",
-						},
-						"entry_id": map[string]interface{}{
-							"type":        "number",
-							"description": "The ID of the entry",
-						},
-					},
-					Required: []string{"category_id", "entry_id"},
-				},
+				Annotations: readOnlyTool("Get Category Entry"),
 			},
+			Group:   toolGroupCategories,
+			Args:    categoryEntryArgs{},
+			Output:  mcp.WithOutputSchema[entryDocument](),
 			Handler: s.GetCategoryEntry,
 		},
 		{
 			Tool: mcp.Tool{
 				Name:        "mark_category_as_read",
 				Description: "Mark all entries in a category as read",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: map[string]interface{}{
-						"category_id": map[string]interface{}{
-							"type":        "number",
-							"description": "The ID of the category",
-						},
-					},
-					Required: []string{"category_id"},
-				},
+				Annotations: destructiveTool("Mark Category as Read", true),
 			},
+			Group:   toolGroupCategories,
+			Args:    categoryArgs{},
 			Handler: s.MarkCategoryAsRead,
 		},
 		{
 			Tool: mcp.Tool{
 				Name:        "refresh_category",
 				Description: "Refresh all feeds in a category",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: map[string]interface{}{
-						"category_id": map[string]interface{}{
-							"type":        "number",
-							"description": "The ID of the category",
-						},
-					},
-					Required: []string{"category_id"},
-				},
+				Annotations: openWorld(updateTool("Refresh Category", true)),
 			},
+			Group:   toolGroupCategories,
+			Args:    categoryArgs{},
 			Handler: s.RefreshCategory,
 		},
 
@@ -683,98 +523,76 @@ func (s *MinifluxServer) RegisterAllTools(mcpServer *server.MCPServer) {
 			Tool: mcp.Tool{
 				Name:        "get_users",
 				Description: "Get all users",
-				InputSchema: mcp.ToolInputSchema{
-					Type:       "object",
-					Properties: map[string]interface{}{},
-				},
+				Annotations: readOnlyTool("Get Users"),
 			},
+			Group:   toolGroupUsers,
+			Args:    noArgs{},
+			Output:  mcp.WithOutputSchema[userList](),
 			Handler: s.GetUsers,
 		},
 		{
 			Tool: mcp.Tool{
 				Name:        "get_me",
 				Description: "Get current user information",
-				InputSchema: mcp.ToolInputSchema{
-					Type:       "object",
-					Properties: map[string]interface{}{},
-				},
+				Annotations: readOnlyTool("Get Current User"),
 			},
+			Group:   toolGroupUsers,
+			Args:    noArgs{},
+			Output:  mcp.WithOutputSchema[client.User](),
 			Handler: s.GetMe,
 		},
 		{
 			Tool: mcp.Tool{
 				Name:        "get_user_by_id",
 				Description: "Get a specific user by ID",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: map[string]interface{}{
-						"user_id": map[string]interface{}{
-							"type":        "number",
-							"description": "The ID of the user",
-						},
-					},
-					Required: []string{"user_id"},
-				},
+				Annotations: readOnlyTool("Get User by ID"),
 			},
+			Group:   toolGroupUsers,
+			Args:    userArgs{},
+			Output:  mcp.WithOutputSchema[client.User](),
 			Handler: s.GetUserByID,
 		},
 		{
 			Tool: mcp.Tool{
 				Name:        "get_user_by_username",
 				Description: "Get a specific user by username",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: map[string]interface{}{
-						"username": map[string]interface{}{
-							"type":        "string",
-							"description": "The username of the user",
-						},
-					},
-					Required: []string{"username"},
-				},
+				Annotations: readOnlyTool("Get User by Username"),
 			},
+			Group:   toolGroupUsers,
+			Args:    usernameArgs{},
+			Output:  mcp.WithOutputSchema[client.User](),
 			Handler: s.GetUserByUsername,
 		},
 		{
 			Tool: mcp.Tool{
 				Name:        "create_user",
 				Description: "Create a new user",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: map[string]interface{}{
-						"username": map[string]interface{}{
-							"type":        "string",
-							"description": "The username for the new user",
-						},
-						"password": map[string]interface{}{
-							"type":        "string",
-							"description": "The password for the new user",
-						},
-						"is_admin": map[string]interface{}{
-							"type":        "boolean",
-							"description": "Whether the user should be an admin",
-						},
-					},
-					Required: []string{"username", "password"},
-				},
+				Annotations: updateTool("Create User", false),
 			},
+			Group:   toolGroupUsers,
+			Args:    createUserArgs{},
+			Output:  mcp.WithOutputSchema[client.User](),
 			Handler: s.CreateUser,
 		},
+		{
+			Tool: mcp.Tool{
+				Name:        "update_user",
+				Description: "Update a user and their settings; the result lists each changed setting with its previous and new value",
+				Annotations: updateTool("Update User", true),
+			},
+			Group:   toolGroupUsers,
+			Args:    updateUserArgs{},
+			Output:  mcp.WithOutputSchema[userUpdate](),
+			Handler: s.UpdateUser,
+		},
 		{
 			Tool: mcp.Tool{
 				Name:        "delete_user",
 				Description: "Delete a user",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: map[string]interface{}{
-						"user_id": map[string]interface{}{
-							"type":        "number",
-							"description": "The ID of the user",
-						},
-					},
-					Required: []string{"user_id"},
-				},
+				Annotations: destructiveTool("Delete User", true),
 			},
+			Group:   toolGroupUsers,
+			Args:    userArgs{},
 			Handler: s.DeleteUser,
 		},
 
@@ -783,72 +601,72 @@ func (s *MinifluxServer) RegisterAllTools(mcpServer *server.MCPServer) {
 			Tool: mcp.Tool{
 				Name:        "get_version",
 				Description: "Get Miniflux version information",
-				InputSchema: mcp.ToolInputSchema{
-					Type:       "object",
-					Properties: map[string]interface{}{},
-				},
+				Annotations: readOnlyTool("Get Version"),
 			},
+			Group:   toolGroupSystem,
+			Args:    noArgs{},
 			Handler: s.GetVersion,
 		},
 		{
 			Tool: mcp.Tool{
 				Name:        "healthcheck",
 				Description: "Perform a health check",
-				InputSchema: mcp.ToolInputSchema{
-					Type:       "object",
-					Properties: map[string]interface{}{},
-				},
+				Annotations: readOnlyTool("Health Check"),
 			},
+			Group:   toolGroupSystem,
+			Args:    noArgs{},
 			Handler: s.Healthcheck,
 		},
 		{
 			Tool: mcp.Tool{
 				Name:        "fetch_counters",
 				Description: "Fetch feed counters",
-				InputSchema: mcp.ToolInputSchema{
-					Type:       "object",
-					Properties: map[string]interface{}{},
-				},
+				Annotations: readOnlyTool("Fetch Counters"),
 			},
+			Group:   toolGroupSystem,
+			Args:    noArgs{},
+			Output:  mcp.WithOutputSchema[feedCounters](),
 			Handler: s.FetchCounters,
 		},
 		{
 			Tool: mcp.Tool{
 				Name:        "discover",
 				Description: "Discover feeds from a URL",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: map[string]interface{}{
-						"url": map[string]interface{}{
-							"type":        "string",
-							"description": "The URL to discover feeds from",
-						},
-					},
-					Required: []string{"url"},
-				},
+				Annotations: openWorld(readOnlyTool("Discover Feeds")),
 			},
+			Group:   toolGroupSystem,
+			Args:    discoverArgs{},
 			Handler: s.Discover,
 		},
 		{
 			Tool: mcp.Tool{
 				Name:        "export",
 				Description: "Export feeds as OPML",
-				InputSchema: mcp.ToolInputSchema{
-					Type:       "object",
-					Properties: map[string]interface{}{},
-				},
+				Annotations: readOnlyTool("Export OPML"),
 			},
+			Group:   toolGroupSystem,
+			Args:    noArgs{},
 			Handler: s.Export,
 		},
+		{
+			Tool: mcp.Tool{
+				Name:        "import_opml",
+				Description: "Import feeds from an OPML document given as text; by default it only previews the new feeds and categories and the feeds already subscribed, and imports once dry_run is false",
+				Annotations: openWorld(updateTool("Import OPML", true)),
+			},
+			Group:   toolGroupSystem,
+			Args:    importOPMLArgs{},
+			Output:  mcp.WithOutputSchema[opmlImportReport](),
+			Handler: s.ImportOPML,
+		},
 		{
 			Tool: mcp.Tool{
 				Name:        "flush_history",
 				Description: "Flush the read history",
-				InputSchema: mcp.ToolInputSchema{
-					Type:       "object",
-					Properties: map[string]interface{}{},
-				},
+				Annotations: destructiveTool("Flush History", true),
 			},
+			Group:   toolGroupSystem,
+			Args:    noArgs{},
 			Handler: s.FlushHistory,
 		},
 
@@ -857,45 +675,32 @@ func (s *MinifluxServer) RegisterAllTools(mcpServer *server.MCPServer) {
 			Tool: mcp.Tool{
 				Name:        "get_api_keys",
 				Description: "Get all API keys",
-				InputSchema: mcp.ToolInputSchema{
-					Type:       "object",
-					Properties: map[string]interface{}{},
-				},
+				Annotations: readOnlyTool("Get API Keys"),
 			},
+			Group:   toolGroupAPIKeys,
+			Args:    noArgs{},
+			Output:  mcp.WithOutputSchema[apiKeyList](),
 			Handler: s.GetAPIKeys,
 		},
 		{
 			Tool: mcp.Tool{
 				Name:        "create_api_key",
 				Description: "Create a new API key",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: map[string]interface{}{
-						"description": map[string]interface{}{
-							"type":        "string",
-							"description": "Description for the API key",
-						},
-					},
-					Required: []string{"description"},
-				},
+				Annotations: updateTool("Create API Key", false),
 			},
+			Group:   toolGroupAPIKeys,
+			Args:    createAPIKeyArgs{},
+			Output:  mcp.WithOutputSchema[client.APIKey](),
 			Handler: s.CreateAPIKey,
 		},
 		{
 			Tool: mcp.Tool{
 				Name:        "delete_api_key",
 				Description: "Delete an API key",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: map[string]interface{}{
-						"api_key_id": map[string]interface{}{
-							"type":        "number",
-							"description": "The ID of the API key",
-						},
-					},
-					Required: []string{"api_key_id"},
-				},
+				Annotations: destructiveTool("Delete API Key", true),
 			},
+			Group:   toolGroupAPIKeys,
+			Args:    apiKeyArgs{},
 			Handler: s.DeleteAPIKey,
 		},
 
@@ -904,40 +709,29 @@ func (s *MinifluxServer) RegisterAllTools(mcpServer *server.MCPServer) {
 			Tool: mcp.Tool{
 				Name:        "get_icon",
 				Description: "Get an icon by ID",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: map[string]interface{}{
-						"icon_id": map[string]interface{}{
-							"type":        "number",
-							"description": "The ID of the icon",
-						},
-					},
-					Required: []string{"icon_id"},
-				},
+				Annotations: readOnlyTool("Get Icon"),
 			},
+			Group:   toolGroupIcons,
+			Args:    iconArgs{},
 			Handler: s.GetIcon,
 		},
 		{
 			Tool: mcp.Tool{
 				Name:        "get_enclosure",
 				Description: "Get an enclosure by ID",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: map[string]interface{}{
-						"enclosure_id": map[string]interface{}{
-							"type":        "number",
-							"description": "The ID of the enclosure",
-						},
-					},
-					Required: []string{"enclosure_id"},
-				},
+				Annotations: readOnlyTool("Get Enclosure"),
 			},
+			Group:   toolGroupIcons,
+			Args:    enclosureArgs{},
 			Handler: s.GetEnclosure,
 		},
 	}
 
-	// Register all tools
-	for _, toolDef := range tools {
-		mcpServer.AddTool(toolDef.Tool, toolDef.Handler)
+	for i := range tools {
+		tools[i].Tool.InputSchema = mustInputSchema(tools[i].Args)
+		if tools[i].Output != nil {
+			tools[i].Output(&tools[i].Tool)
+		}
 	}
+	return tools
 }
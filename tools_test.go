@@ -0,0 +1,314 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"miniflux.app/v2/client"
+)
+
+func TestToolSchemasMatchHandlerArguments(t *testing.T) {
+	minifluxServer := &MinifluxServer{}
+	for _, toolDef := range minifluxServer.toolDefinitions() {
+		t.Run(toolDef.Tool.Name, func(t *testing.T) {
+			// Handlers reject unknown arguments before touching the client
+			// and report every argument they bind, which is exactly the set
+			// the schema must declare.
+			request := mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Name:      toolDef.Tool.Name,
+					Arguments: map[string]interface{}{"undeclared_argument": true},
+				},
+			}
+			result, err := toolDef.Handler(context.Background(), request)
+			if err != nil {
+				t.Fatalf("handler returned error: %v", err)
+			}
+			structured, ok := result.StructuredContent.(map[string]any)
+			if !result.IsError || !ok {
+				t.Fatalf("handler accepted an undeclared argument: %#v", result)
+			}
+			argErr, ok := structured["error"].(*argumentError)
+			if !ok || argErr.Reason != reasonUnknownArgument {
+				t.Fatalf("structured error = %#v, want %s", structured["error"], reasonUnknownArgument)
+			}
+
+			bound := slices.Sorted(slices.Values(argErr.Accepted))
+			declared := slices.Sorted(maps.Keys(toolDef.Tool.InputSchema.Properties))
+			if !reflect.DeepEqual(bound, declared) {
+				t.Errorf("handler binds %v, schema declares %v", bound, declared)
+			}
+			for _, name := range toolDef.Tool.InputSchema.Required {
+				if !slices.Contains(declared, name) {
+					t.Errorf("required argument %q is not declared", name)
+				}
+			}
+		})
+	}
+}
+
+func TestToolInputSchemas(t *testing.T) {
+	tools := map[string]mcp.Tool{}
+	for _, toolDef := range (&MinifluxServer{}).toolDefinitions() {
+		tools[toolDef.Tool.Name] = toolDef.Tool
+	}
+
+	getFeed := tools["get_feed"].InputSchema
+	if len(getFeed.Required) != 0 {
+		t.Errorf("get_feed required = %v, want none since feed_id and feed are alternatives", getFeed.Required)
+	}
+	if getFeed.AdditionalProperties != false {
+		t.Errorf("get_feed additionalProperties = %#v, want false", getFeed.AdditionalProperties)
+	}
+	feedID := getFeed.Properties["feed_id"].(map[string]any)
+	if feedID["type"] != "integer" || feedID["minimum"] != int64(1) || feedID["description"] == "" {
+		t.Errorf("feed_id schema = %#v, want a described integer with minimum 1", feedID)
+	}
+	if feed := getFeed.Properties["feed"].(map[string]any); feed["type"] != "string" {
+		t.Errorf("feed schema = %#v, want a string", feed)
+	}
+
+	if _, ok := tools["get_category_entries"].InputSchema.Properties["offset"]; !ok {
+		t.Error("get_category_entries does not declare offset")
+	}
+
+	status := tools["update_entry_status"].InputSchema.Properties["status"].(map[string]any)
+	if !reflect.DeepEqual(status["enum"], []string{"read", "unread", "removed"}) {
+		t.Errorf("update_entry_status status enum = %#v", status["enum"])
+	}
+
+	statuses := tools["get_entries"].InputSchema.Properties["statuses"].(map[string]any)
+	items := statuses["items"].(map[string]any)
+	if statuses["type"] != "array" || items["type"] != "string" || items["enum"] == nil {
+		t.Errorf("get_entries statuses schema = %#v, want an array of enumerated strings", statuses)
+	}
+
+	for _, name := range []string{"category_id", "category"} {
+		if _, ok := tools["create_feed"].InputSchema.Properties[name]; !ok {
+			t.Errorf("create_feed does not declare %s", name)
+		}
+	}
+}
+
+func TestToolAnnotations(t *testing.T) {
+	const (
+		read        = "read"
+		update      = "update"
+		destructive = "destructive"
+	)
+	type classification struct {
+		kind       string
+		idempotent bool
+		openWorld  bool
+	}
+	// Every registered tool must be classified here, so new tools cannot
+	// ship without a deliberate decision about their hints.
+	expected := map[string]classification{
+		"get_feeds":              {read, true, false},
+		"get_feed":               {read, true, false},
+		"create_feed":            {update, false, true},
+		"subscribe":              {update, true, true},
+		"update_feed":            {update, true, false},
+		"delete_feed":            {destructive, true, false},
+		"refresh_feed":           {update, true, true},
+		"refresh_all_feeds":      {update, true, true},
+		"get_feed_entries":       {read, true, false},
+		"get_feed_entry":         {read, true, false},
+		"get_feed_icon":          {read, true, false},
+		"mark_feed_as_read":      {destructive, true, false},
+		"find_duplicate_feeds":   {read, true, false},
+		"dedupe_feeds":           {destructive, true, false},
+		"feed_health":            {read, true, false},
+		"get_entries":            {read, true, false},
+		"get_entry":              {read, true, false},
+		"update_entry_status":    {destructive, true, false},
+		"update_entries_status":  {destructive, true, false},
+		"update_entry":           {update, true, false},
+		"toggle_starred":         {update, false, false},
+		"set_starred":            {update, true, false},
+		"save_entry":             {update, false, true},
+		"fetch_original_content": {read, true, true},
+		"mark_all_as_read":       {destructive, true, false},
+		"get_categories":         {read, true, false},
+		"create_category":        {update, false, false},
+		"update_category":        {update, true, false},
+		"delete_category":        {destructive, true, false},
+		"merge_categories":       {destructive, false, false},
+		"get_category_feeds":     {read, true, false},
+		"get_category_entries":   {read, true, false},
+		"get_category_entry":     {read, true, false},
+		"mark_category_as_read":  {destructive, true, false},
+		"refresh_category":       {update, true, true},
+		"get_users":              {read, true, false},
+		"get_me":                 {read, true, false},
+		"get_user_by_id":         {read, true, false},
+		"get_user_by_username":   {read, true, false},
+		"create_user":            {update, false, false},
+		"update_user":            {update, true, false},
+		"delete_user":            {destructive, true, false},
+		"get_version":            {read, true, false},
+		"healthcheck":            {read, true, false},
+		"fetch_counters":         {read, true, false},
+		"discover":               {read, true, true},
+		"export":                 {read, true, false},
+		"import_opml":            {update, true, true},
+		"flush_history":          {destructive, true, false},
+		"get_api_keys":           {read, true, false},
+		"create_api_key":         {update, false, false},
+		"delete_api_key":         {destructive, true, false},
+		"get_icon":               {read, true, false},
+		"get_enclosure":          {read, true, false},
+	}
+
+	registered := map[string]bool{}
+	for _, toolDef := range (&MinifluxServer{}).toolDefinitions() {
+		name := toolDef.Tool.Name
+		registered[name] = true
+		want, ok := expected[name]
+		if !ok {
+			t.Errorf("%s is not classified", name)
+			continue
+		}
+
+		annotations := toolDef.Tool.Annotations
+		if annotations.Title == "" {
+			t.Errorf("%s has no title", name)
+		}
+		hints := map[string]*bool{
+			"readOnlyHint":    annotations.ReadOnlyHint,
+			"destructiveHint": annotations.DestructiveHint,
+			"idempotentHint":  annotations.IdempotentHint,
+			"openWorldHint":   annotations.OpenWorldHint,
+		}
+		wantHints := map[string]bool{
+			"readOnlyHint":    want.kind == read,
+			"destructiveHint": want.kind == destructive,
+			"idempotentHint":  want.idempotent,
+			"openWorldHint":   want.openWorld,
+		}
+		for hint, value := range hints {
+			if value == nil {
+				t.Errorf("%s does not set %s", name, hint)
+			} else if *value != wantHints[hint] {
+				t.Errorf("%s %s = %t, want %t", name, hint, *value, wantHints[hint])
+			}
+		}
+	}
+	for name := range expected {
+		if !registered[name] {
+			t.Errorf("%s is classified but not registered", name)
+		}
+	}
+}
+
+// callTool dispatches a tools/call request through the MCP server, so server
+// options such as middleware and output validation apply.
+func callTool(t *testing.T, mcpServer *server.MCPServer, name string, arguments map[string]any) *mcp.CallToolResult {
+	t.Helper()
+
+	message, err := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "tools/call",
+		"params":  map[string]any{"name": name, "arguments": arguments},
+	})
+	if err != nil {
+		t.Fatalf("marshal request: %v", err)
+	}
+
+	response, ok := mcpServer.HandleMessage(context.Background(), message).(mcp.JSONRPCResponse)
+	if !ok {
+		t.Fatalf("tools/call %s returned %#v", name, response)
+	}
+	result, ok := response.Result.(*mcp.CallToolResult)
+	if !ok {
+		t.Fatalf("result = %#v, want *mcp.CallToolResult", response.Result)
+	}
+	return result
+}
+
+func TestReadOnlyModeRegistersOnlyReadOnlyTools(t *testing.T) {
+	mcpServer := newMCPServer(&MinifluxServer{tools: toolConfig{ReadOnly: true}})
+	registered := mcpServer.ListTools()
+
+	for _, name := range []string{
+		"create_feed", "update_feed", "delete_feed", "refresh_all_feeds", "mark_feed_as_read", "dedupe_feeds",
+		"update_entry_status", "toggle_starred", "save_entry", "mark_all_as_read",
+		"create_category", "delete_category", "merge_categories", "create_user", "delete_user",
+		"flush_history", "create_api_key", "delete_api_key",
+	} {
+		if _, ok := registered[name]; ok {
+			t.Errorf("%s is registered in read-only mode", name)
+		}
+	}
+	for _, name := range []string{"get_feeds", "get_entries", "get_categories", "get_me", "export"} {
+		if _, ok := registered[name]; !ok {
+			t.Errorf("%s is not registered in read-only mode", name)
+		}
+	}
+	for name, tool := range registered {
+		if hint := tool.Tool.Annotations.ReadOnlyHint; hint == nil || !*hint {
+			t.Errorf("%s is registered in read-only mode but is not read-only", name)
+		}
+	}
+
+	all := newMCPServer(&MinifluxServer{}).ListTools()
+	if _, ok := all["delete_feed"]; !ok {
+		t.Error("delete_feed is not registered outside read-only mode")
+	}
+}
+
+func TestReadOnlyModeRefusesMutatingCalls(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("read-only mode sent %s %s to Miniflux", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer apiServer.Close()
+
+	minifluxServer := &MinifluxServer{
+		client: client.NewClient(apiServer.URL, "test-api-key"),
+		tools:  toolConfig{ReadOnly: true},
+	}
+	mcpServer := newMCPServer(minifluxServer)
+	// Register mutating tools behind RegisterAllTools' back; the dispatch
+	// guard must still refuse them.
+	for _, toolDef := range minifluxServer.toolDefinitions() {
+		switch toolDef.Tool.Name {
+		case "delete_feed", "flush_history":
+			mcpServer.AddTool(toolDef.Tool, toolDef.Handler)
+		}
+	}
+
+	for name, arguments := range map[string]map[string]any{
+		"delete_feed":   {"feed_id": 42},
+		"flush_history": {},
+	} {
+		result := callTool(t, mcpServer, name, arguments)
+		if !result.IsError {
+			t.Errorf("%s succeeded in read-only mode", name)
+		}
+	}
+
+	if result := callTool(t, mcpServer, "get_feeds", map[string]any{}); result.IsError {
+		t.Errorf("get_feeds failed in read-only mode: %#v", result.Content)
+	}
+}
+
+func TestToolGroups(t *testing.T) {
+	for _, toolDef := range (&MinifluxServer{}).toolDefinitions() {
+		if !slices.Contains(toolGroups, toolDef.Group) {
+			t.Errorf("%s has unknown group %q", toolDef.Tool.Name, toolDef.Group)
+		}
+	}
+}
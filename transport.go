@@ -17,6 +17,7 @@ const (
 	transportStreamableHTTP = "streamable-http"
 	defaultHTTPAddr         = ":8080"
 	defaultHTTPPath         = "/mcp"
+	defaultPollInterval     = time.Minute
 )
 
 type transportConfig struct {
@@ -24,6 +25,10 @@ type transportConfig struct {
 	HTTPAddr  string
 	HTTPPath  string
 	AuthToken string
+	// Stateful keeps HTTP sessions between requests, which resource
+	// subscriptions need.
+	Stateful     bool
+	PollInterval time.Duration
 }
 
 func loadTransportConfig() (transportConfig, error) {
@@ -33,6 +38,18 @@ func loadTransportConfig() (transportConfig, error) {
 		HTTPPath:  envOrDefault("MCP_HTTP_PATH", defaultHTTPPath),
 		AuthToken: os.Getenv("MCP_AUTH_TOKEN"),
 	}
+	stateful, err := envBool("MCP_HTTP_STATEFUL", false)
+	if err != nil {
+		return transportConfig{}, err
+	}
+	cfg.Stateful = stateful
+	cfg.PollInterval = defaultPollInterval
+	if value := os.Getenv("MCP_POLL_INTERVAL"); value != "" {
+		cfg.PollInterval, err = time.ParseDuration(value)
+		if err != nil || cfg.PollInterval < time.Second {
+			return transportConfig{}, fmt.Errorf("MCP_POLL_INTERVAL must be a duration of at least 1s, got %q", value)
+		}
+	}
 
 	switch cfg.Transport {
 	case transportStdio:
@@ -53,6 +70,12 @@ func loadTransportConfig() (transportConfig, error) {
 	}
 }
 
+// deliversNotifications reports whether the transport keeps sessions that
+// server notifications can reach.
+func (cfg transportConfig) deliversNotifications() bool {
+	return cfg.Transport == transportStdio || cfg.Stateful
+}
+
 func envOrDefault(name, fallback string) string {
 	if value := os.Getenv(name); value != "" {
 		return value
@@ -72,10 +95,11 @@ func serveMCP(mcpServer *server.MCPServer, cfg transportConfig) error {
 }
 
 func serveStreamableHTTP(mcpServer *server.MCPServer, cfg transportConfig) error {
-	mcpHandler := server.NewStreamableHTTPServer(
-		mcpServer,
-		server.WithStateLess(true),
-	)
+	sessionOption := server.WithStateLess(true)
+	if cfg.Stateful {
+		sessionOption = server.WithStateful(true)
+	}
+	mcpHandler := server.NewStreamableHTTPServer(mcpServer, sessionOption)
 
 	mux := http.NewServeMux()
 	mux.Handle(cfg.HTTPPath, requireBearerToken(cfg.AuthToken, mcpHandler))